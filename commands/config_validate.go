@@ -0,0 +1,49 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/config"
+)
+
+var ConfigValidateCommand = &cobra.Command{
+	Use:  "validate",
+	Long: "validate command checks the config file, and the base configs it extends, for unknown keys, values of wrong types, unknown SPDX IDs, invalid globs and invalid regular expressions.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		problems, err := config.Validate(configFile)
+		if err != nil {
+			return err
+		}
+
+		if len(problems) > 0 {
+			for _, problem := range problems {
+				logger.Log.Errorln(problem)
+			}
+			return fmt.Errorf("%d problem(s) found in the config file", len(problems))
+		}
+
+		logger.Log.Infoln("The config file is valid:", configFile)
+
+		return nil
+	},
+}
@@ -31,4 +31,5 @@ var Deps = &cobra.Command{
 func init() {
 	Deps.AddCommand(DepsResolveCommand)
 	Deps.AddCommand(DepsCheckCommand)
+	Deps.AddCommand(DepsWhyCommand)
 }
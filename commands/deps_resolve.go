@@ -38,6 +38,8 @@ var (
 	licensePath    string
 	summaryTplPath string
 	summaryTpl     *template.Template
+	sbomOutputs    []string
+	projectDir     string
 )
 
 func init() {
@@ -48,6 +50,9 @@ func init() {
 			"created in the same directory as the template file, to save the final summary.")
 	DepsResolveCommand.PersistentFlags().StringVarP(&licensePath, "license", "l", "",
 		"the path to the LICENSE file to be generated. The default summary format will be used if summary template file is not specified")
+	DepsResolveCommand.PersistentFlags().StringArrayVar(&sbomOutputs, "sbom", nil,
+		fmt.Sprintf("write the SBOM document of the dependencies, in the form of <format>=<file>, the format is one of %q, "+
+			"it can be repeated to write multiple documents", deps.SBOMFormats))
 }
 
 var fileNamePattern = regexp.MustCompile(`[^a-zA-Z0-9\\.\-]`)
@@ -79,6 +84,23 @@ var DepsResolveCommand = &cobra.Command{
 			}
 			summaryTpl = tpl
 		}
+		// the resolvers may change the working directory, so the paths are resolved against the current one in advance
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		projectDir = wd
+		for i, output := range sbomOutputs {
+			format, path, err := parseSBOMOutput(output)
+			if err != nil {
+				return err
+			}
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			sbomOutputs[i] = string(format) + "=" + absPath
+		}
 		if licensePath != "" {
 			absPath, err := filepath.Abs(licensePath)
 			if err != nil {
@@ -119,6 +141,12 @@ var DepsResolveCommand = &cobra.Command{
 			}
 		}
 
+		for _, output := range sbomOutputs {
+			if err := writeSBOM(&report, output); err != nil {
+				return err
+			}
+		}
+
 		fmt.Println(report.String())
 
 		if skipped := len(report.Skipped); skipped > 0 {
@@ -177,3 +205,42 @@ func writeSummary(rep *deps.Report, path string) error {
 	_, err = file.WriteString(summary)
 	return err
 }
+
+// parseSBOMOutput parses the SBOM output in the form of <format>=<file>.
+func parseSBOMOutput(output string) (deps.SBOMFormat, string, error) {
+	f, file, ok := strings.Cut(output, "=")
+	if !ok || file == "" {
+		return "", "", fmt.Errorf("invalid --sbom %q, expected <format>=<file>", output)
+	}
+	format, err := deps.ParseSBOMFormat(f)
+	return format, file, err
+}
+
+func writeSBOM(report *deps.Report, output string) error {
+	format, path, err := parseSBOMOutput(output)
+	if err != nil {
+		return err
+	}
+
+	sbom := &deps.SBOM{Tool: "license-eye-" + version, Name: filepath.Base(projectDir)}
+	if headers := Config.Headers(); len(headers) > 0 {
+		if name := headers[0].License.SoftwareName; name != "" {
+			sbom.Name = name
+		}
+		sbom.License = headers[0].License.SpdxID
+		sbom.Supplier = headers[0].License.CopyrightOwner
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := deps.WriteSBOM(file, format, report, sbom); err != nil {
+		return err
+	}
+	logger.Log.Infof("Wrote the %v SBOM document: %v", format, path)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+var DepsWhyCommand = &cobra.Command{
+	Use:   "why <dependency>",
+	Short: "Explain why a dependency is brought into the project",
+	Long: "why resolves all dependencies of a module and prints the paths from the project to the given dependency, " +
+		"which is the name or the package URL of the dependency, so that you know which direct dependency brings it",
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		report := deps.Report{}
+		if err := deps.Resolve(Config.Dependencies(), &report); err != nil {
+			return err
+		}
+
+		results := report.Find(args[0])
+		if len(results) == 0 {
+			return fmt.Errorf("dependency %v is not found", args[0])
+		}
+
+		for _, result := range results {
+			fmt.Println(why(&report, result))
+		}
+		return nil
+	},
+}
+
+// why returns the paths from the project to the dependency of the result.
+func why(report *deps.Report, result *deps.Result) string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%v@%v (%v)", result.Dependency, result.Version, result.LicenseSpdxID)
+
+	paths := report.Paths(result.Dependency)
+	if len(paths) == 0 {
+		if result.Direct {
+			s.WriteString(" is a direct dependency\n")
+		} else {
+			s.WriteString(" is not reachable in the dependency graph, the resolver may not record it\n")
+		}
+		return s.String()
+	}
+
+	root := result.Manifest
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, root); err == nil {
+			root = rel
+		}
+	}
+	s.WriteString(" is introduced by:\n")
+	for _, path := range paths {
+		fmt.Fprintf(&s, "  %v\n", strings.Join(append([]string{root}, path...), " -> "))
+	}
+	return s.String()
+}
@@ -18,17 +18,58 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/pkg/header"
 )
 
 var Header = &cobra.Command{
 	Use:     "header",
 	Aliases: []string{"h"},
-	Short:   "License header related commands; e.g. check, fix, etc.",
+	Short:   "License header related commands; e.g. check, fix, remove, migrate, etc.",
 	Long:    "`header` command walks the specified paths recursively and checks if the specified files have the license header in the config file.",
 }
 
+var (
+	jobs   int
+	since  string
+	staged bool
+)
+
 func init() {
+	Header.PersistentFlags().IntVarP(&jobs, "jobs", "j", 0,
+		"the number of files to check (or fix) concurrently, overrides the 'jobs' in the config file if set")
+	Header.PersistentFlags().StringVar(&since, "since", "",
+		"only check (or fix) the files added or modified since the git revision, e.g. origin/main")
+	Header.PersistentFlags().StringVar(&since, "base", "", "alias of --since")
+	Header.PersistentFlags().BoolVar(&staged, "staged", false,
+		"only check (or fix) the files staged in the git index, the staged contents are used instead of the working tree")
+
 	Header.AddCommand(CheckCommand)
 	Header.AddCommand(FixCommand)
+	Header.AddCommand(RemoveCommand)
+	Header.AddCommand(MigrateCommand)
+}
+
+// checkHeaderFlags validates the combination of the command line flags.
+func checkHeaderFlags(args []string) error {
+	if staged && since != "" {
+		return fmt.Errorf("--staged and --since cannot be used together")
+	}
+	if staged && len(args) > 0 {
+		return fmt.Errorf("--staged cannot be used with paths")
+	}
+	return nil
+}
+
+// applyHeaderFlags overrides the header config with the command line flags.
+func applyHeaderFlags(h *header.ConfigHeader) {
+	if jobs > 0 {
+		h.Jobs = jobs
+	}
+	if since != "" {
+		h.Since = since
+	}
 }
@@ -28,36 +28,66 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	outputFormat string
+	outputFile   string
+)
+
+func init() {
+	CheckCommand.Flags().StringVarP(&outputFormat, "format", "f", string(header.FormatText),
+		fmt.Sprintf("the format of the check results, one of %v", header.Formats))
+	CheckCommand.Flags().StringVarP(&outputFile, "output", "o", "",
+		"the file to write the check results to, if not set the results are written to the standard output")
+}
+
 var CheckCommand = &cobra.Command{
 	Use:     "check",
 	Aliases: []string{"c"},
 	Long:    "check command walks the specified paths recursively and checks if the specified files have the license header in the config file.",
 	RunE: func(_ *cobra.Command, args []string) error {
+		format, err := header.ParseFormat(outputFormat)
+		if err != nil {
+			return err
+		}
+		if err := checkHeaderFlags(args); err != nil {
+			return err
+		}
+
 		hasErrors := false
-		for _, h := range Config.Headers() {
-			var result header.Result
+		var reports []*header.Report
+		for i, h := range Config.Headers() {
+			result := &header.Result{}
 
 			if len(args) > 0 {
 				logger.Log.Debugln("Overriding paths with command line args.")
 				h.Paths = args
 			}
+			applyHeaderFlags(h)
 
-			if err := header.Check(h, &result); err != nil {
+			check := header.Check
+			if staged {
+				check = header.CheckStaged
+			}
+			if err := check(h, result); err != nil {
 				return err
 			}
 
 			logger.Log.Infoln(result.String())
 
-			writeSummaryQuietly(&result)
+			writeSummaryQuietly(result)
+			reports = append(reports, &header.Report{Index: i, Config: h, Result: result})
 
 			if result.HasFailure() {
-				if err := review.Header(&result, h); err != nil {
+				if err := review.Header(result, h); err != nil {
 					logger.Log.Warnln("Failed to create review comments", err)
 				}
 				hasErrors = true
 				logger.Log.Error(result.Error())
 			}
 		}
+		if err := writeReports(format, reports); err != nil {
+			return err
+		}
 		if hasErrors {
 			return fmt.Errorf("one or more files does not have a valid license header")
 		}
@@ -65,6 +95,23 @@ var CheckCommand = &cobra.Command{
 	},
 }
 
+func writeReports(format header.Format, reports []*header.Report) error {
+	if outputFile == "" {
+		if format == header.FormatText {
+			return nil // the results are already logged
+		}
+		return header.WriteReports(os.Stdout, format, reports)
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return header.WriteReports(file, format, reports)
+}
+
 func writeSummaryQuietly(result *header.Result) {
 	if summaryFileName := os.Getenv("GITHUB_STEP_SUMMARY"); summaryFileName != "" {
 		if summaryFile, err := os.OpenFile(summaryFileName, os.O_WRONLY|os.O_APPEND, 0o644); err == nil {
@@ -74,7 +121,7 @@ func writeSummaryQuietly(result *header.Result) {
 			if result.HasFailure() {
 				_, _ = summaryFile.WriteString(", the following files are lack of license headers:\n")
 				for _, failure := range result.Failure {
-					_, _ = fmt.Fprintf(summaryFile, "- %s\n", failure)
+					_, _ = fmt.Fprintf(summaryFile, "- %s: %s\n", failure, result.Diagnostic(failure).Message)
 				}
 			}
 		}
@@ -19,6 +19,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -27,28 +28,63 @@ import (
 	"github.com/apache/skywalking-eyes/pkg/header"
 )
 
+var (
+	replace   bool
+	dryRun    bool
+	patchFile string
+)
+
+func init() {
+	FixCommand.Flags().BoolVar(&replace, "replace", false,
+		"replace the existing license headers of other licenses with the configured one, except the protected-headers")
+	FixCommand.Flags().BoolVar(&dryRun, "dry-run", false,
+		"print the fixes as unified diffs instead of writing the files, and fail if any file would be fixed")
+	FixCommand.Flags().StringVar(&patchFile, "patch", "",
+		"write the fixes into the patch file instead of the standard output, implies --dry-run")
+}
+
 var FixCommand = &cobra.Command{
 	Use:     "fix",
 	Aliases: []string{"f"},
 	Long:    "fix command walks the specified paths recursively and fix the license header if the specified files don't have the license header.",
 	RunE: func(_ *cobra.Command, args []string) error {
+		if err := checkHeaderFlags(args); err != nil {
+			return err
+		}
+
+		if patchFile != "" {
+			dryRun = true
+		}
+
 		var errors []string
+		var results []*header.Result
 		for _, h := range Config.Headers() {
-			var result header.Result
+			result := &header.Result{}
 			var files []string
 
+			applyHeaderFlags(h)
+			h.Replace = replace
+			h.DryRun = dryRun
+			results = append(results, result)
+
+			if staged {
+				for _, err := range header.FixStaged(h, result) {
+					errors = append(errors, err.Error())
+				}
+				logger.Log.Infoln(result.String())
+				continue
+			}
+
 			if len(args) > 0 {
 				files = args
-			} else if err := header.Check(h, &result); err != nil {
+			} else if err := header.Check(h, result); err != nil {
 				return err
 			} else {
 				files = result.Failure
 			}
 
-			for _, file := range files {
-				if err := header.Fix(file, h, &result); err != nil {
-					errors = append(errors, err.Error())
-				}
+			for _, err := range header.FixFiles(files, h, result) {
+				errors = append(errors, err.Error())
 			}
 
 			logger.Log.Infoln(result.String())
@@ -56,6 +92,37 @@ var FixCommand = &cobra.Command{
 		if len(errors) > 0 {
 			return fmt.Errorf("%s", strings.Join(errors, "\n"))
 		}
+		if dryRun {
+			return writePatches(results)
+		}
 		return nil
 	},
 }
+
+func writePatches(results []*header.Result) error {
+	fixed := 0
+	for _, result := range results {
+		fixed += len(result.Fixed)
+	}
+
+	if patchFile == "" {
+		if err := header.WritePatches(os.Stdout, results...); err != nil {
+			return err
+		}
+	} else {
+		file, err := os.Create(patchFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if err := header.WritePatches(file, results...); err != nil {
+			return err
+		}
+	}
+
+	if fixed > 0 {
+		return fmt.Errorf("%d file(s) would be fixed", fixed)
+	}
+	return nil
+}
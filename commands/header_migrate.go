@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/config"
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+var (
+	migrateFrom string
+	migrateTo   string
+)
+
+func init() {
+	MigrateCommand.Flags().StringVar(&migrateFrom, "from", "", "the config file of the license headers to migrate from")
+	MigrateCommand.Flags().StringVar(&migrateTo, "to", "", "the config file of the license headers to migrate to")
+	MigrateCommand.Flags().BoolVar(&dryRun, "dry-run", false,
+		"print the migrations as unified diffs instead of writing the files, and fail if any file would be changed")
+	MigrateCommand.Flags().StringVar(&patchFile, "patch", "",
+		"write the migrations into the patch file instead of the standard output, implies --dry-run")
+	_ = MigrateCommand.MarkFlagRequired("from")
+	_ = MigrateCommand.MarkFlagRequired("to")
+}
+
+var MigrateCommand = &cobra.Command{
+	Use:     "migrate",
+	Aliases: []string{"m"},
+	Long: "migrate command rewrites the license headers of the config file specified by --from to the ones of the config file " +
+		"specified by --to, the headers in the two config files are paired by their orders.",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if staged {
+			return fmt.Errorf("--staged is not supported by the migrate command")
+		}
+		if patchFile != "" {
+			dryRun = true
+		}
+
+		from, err := loadHeaders(migrateFrom)
+		if err != nil {
+			return err
+		}
+		to, err := loadHeaders(migrateTo)
+		if err != nil {
+			return err
+		}
+		if len(from) != len(to) {
+			return fmt.Errorf("the config files have different numbers of headers, %d in %v and %d in %v",
+				len(from), migrateFrom, len(to), migrateTo)
+		}
+
+		var errors []string
+		var results []*header.Result
+		for i := range to {
+			if len(args) > 0 {
+				to[i].Paths = args
+			}
+			applyHeaderFlags(to[i])
+			to[i].DryRun = dryRun
+
+			result := &header.Result{}
+			results = append(results, result)
+			for _, err := range header.Migrate(from[i], to[i], result) {
+				errors = append(errors, err.Error())
+			}
+
+			logger.Log.Infoln(result.String())
+			if result.HasFailure() {
+				logger.Log.Warn(result.Error())
+			}
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("%s", strings.Join(errors, "\n"))
+		}
+		if dryRun {
+			return writePatches(results)
+		}
+		return nil
+	},
+}
+
+func loadHeaders(file string) ([]*header.ConfigHeader, error) {
+	if _, err := os.Stat(file); err != nil {
+		return nil, err
+	}
+	c, err := config.NewConfigFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return c.Headers(), nil
+}
@@ -0,0 +1,98 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+var (
+	removeSpdxID  string
+	removePattern string
+)
+
+func init() {
+	RemoveCommand.Flags().StringVar(&removeSpdxID, "spdx-id", "", "remove the license headers of the SPDX ID")
+	RemoveCommand.Flags().StringVar(&removePattern, "pattern", "",
+		"remove the license headers matching the pattern, a regexp of the license text like the 'pattern' in the config file")
+	RemoveCommand.Flags().BoolVar(&dryRun, "dry-run", false,
+		"print the removals as unified diffs instead of writing the files, and fail if any file would be changed")
+	RemoveCommand.Flags().StringVar(&patchFile, "patch", "",
+		"write the removals into the patch file instead of the standard output, implies --dry-run")
+}
+
+var RemoveCommand = &cobra.Command{
+	Use:     "remove",
+	Aliases: []string{"rm"},
+	Long:    "remove command walks the paths in the config file (or the specified paths) and removes the license headers matching the SPDX ID or pattern.",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if removeSpdxID == "" && removePattern == "" {
+			return fmt.Errorf("either --spdx-id or --pattern is required")
+		}
+		if staged {
+			return fmt.Errorf("--staged is not supported by the remove command")
+		}
+		if patchFile != "" {
+			dryRun = true
+		}
+
+		var errors []string
+		var results []*header.Result
+		for _, h := range Config.Headers() {
+			applyHeaderFlags(h)
+
+			remove := &header.ConfigHeader{
+				License:                  header.LicenseConfig{SpdxID: removeSpdxID, Pattern: removePattern},
+				Paths:                    h.Paths,
+				PathsIgnore:              h.PathsIgnore,
+				LicenseLocationThreshold: h.LicenseLocationThreshold,
+				Languages:                h.Languages,
+				Jobs:                     h.Jobs,
+				Since:                    h.Since,
+				DryRun:                   dryRun,
+			}
+			if len(args) > 0 {
+				remove.Paths = args
+			}
+			if err := remove.Finalize(); err != nil {
+				return err
+			}
+
+			result := &header.Result{}
+			results = append(results, result)
+			for _, err := range header.Remove(remove, result) {
+				errors = append(errors, err.Error())
+			}
+
+			logger.Log.Infoln(result.String())
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("%s", strings.Join(errors, "\n"))
+		}
+		if dryRun {
+			return writePatches(results)
+		}
+		return nil
+	},
+}
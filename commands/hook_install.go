@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+)
+
+var (
+	hookFix     bool
+	hookForce   bool
+	hookCommand string
+)
+
+func init() {
+	HookInstallCommand.Flags().BoolVar(&hookFix, "fix", false,
+		"fix the license headers of the staged files and stage the fixes, instead of only checking them")
+	HookInstallCommand.Flags().BoolVar(&hookForce, "force", false, "overwrite the existing pre-commit hook")
+	HookInstallCommand.Flags().StringVar(&hookCommand, "command", "license-eye",
+		"the command to run license-eye in the hook, e.g. the absolute path of the binary")
+}
+
+var HookInstallCommand = &cobra.Command{
+	Use:  "install",
+	Long: "install command writes a git pre-commit hook that checks (or fixes) the license headers of the staged files.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		repo, err := git.PlainOpen("./")
+		if err != nil {
+			return fmt.Errorf("installing the hook requires the root of a git repository: %w", err)
+		}
+
+		hooksDir, err := hooksDirectory(repo)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+			return err
+		}
+
+		hookFile := filepath.Join(hooksDir, "pre-commit")
+		if _, err := os.Stat(hookFile); err == nil && !hookForce {
+			return fmt.Errorf("the pre-commit hook %v already exists, use --force to overwrite it", hookFile)
+		}
+
+		if err := os.WriteFile(hookFile, []byte(preCommitHook()), 0o755); err != nil { //nolint:gosec // hooks must be executable
+			return err
+		}
+
+		logger.Log.Infoln("Installed the pre-commit hook:", hookFile)
+
+		return nil
+	},
+}
+
+// hooksDirectory returns the directory of the git hooks, respecting the core.hooksPath option.
+func hooksDirectory(repo *git.Repository) (string, error) {
+	if cfg, err := repo.Config(); err == nil {
+		if hooksPath := cfg.Raw.Section("core").Option("hooksPath"); hooksPath != "" {
+			return hooksPath, nil
+		}
+	}
+
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", fmt.Errorf("unsupported git repository storage")
+	}
+
+	return filepath.Join(storage.Filesystem().Root(), "hooks"), nil
+}
+
+func preCommitHook() string {
+	action, description := "check", "checks"
+	if hookFix {
+		action, description = "fix", "fixes"
+	}
+
+	return strings.Join([]string{
+		"#!/bin/sh",
+		"# Installed by `license-eye hook install`, " + description + " the license headers of the staged files.",
+		fmt.Sprintf("exec %s -c %s header %s --staged", hookCommand, shellQuote(configFile), action),
+		"",
+	}, "\n")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/config"
+)
+
+var (
+	initLicense        string
+	initOwner          string
+	initManifests      []string
+	initPathsIgnore    []string
+	initForce          bool
+	initNonInteractive bool
+)
+
+func init() {
+	InitCommand.Flags().StringVar(&initLicense, "license", "", "the SPDX ID of the license, instead of the one identified from the license file")
+	InitCommand.Flags().StringVar(&initOwner, "owner", "", "the copyright owner, instead of the one found in the existing license headers or git")
+	InitCommand.Flags().StringSliceVar(&initManifests, "manifest", nil, "the dependency manifest files, instead of the ones found in the repository")
+	InitCommand.Flags().StringSliceVar(&initPathsIgnore, "paths-ignore", nil, "the additional paths-ignore")
+	InitCommand.Flags().BoolVar(&initForce, "force", false, "overwrite the existing config file")
+	InitCommand.Flags().BoolVarP(&initNonInteractive, "non-interactive", "y", false,
+		"don't prompt for the license and the copyright owner, use the flags and the scan result instead")
+}
+
+var InitCommand = &cobra.Command{
+	Use:  "init",
+	Long: "init command scans the repository and writes a ready-to-use config file, with the license identified from the license file, the copyright owner found in the existing license headers or git, the dependency manifest files, and the paths-ignore for the generated files and the files that cannot have license headers.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if _, err := os.Stat(configFile); err == nil && !initForce {
+			return fmt.Errorf("the config file %v already exists, use --force to overwrite it", configFile)
+		}
+
+		scan, err := config.ScanRepo()
+		if err != nil {
+			return err
+		}
+		if initLicense != "" {
+			scan.License = initLicense
+		}
+		if initOwner != "" {
+			scan.Owner = initOwner
+		}
+		if initManifests != nil {
+			scan.Manifests = initManifests
+		}
+		scan.PathsIgnore = append(scan.PathsIgnore, initPathsIgnore...)
+
+		if !initNonInteractive && isTerminal(os.Stdin) {
+			in := bufio.NewReader(os.Stdin)
+			scan.License = prompt(in, "License (SPDX ID)", scan.License)
+			scan.Owner = prompt(in, "Copyright owner", scan.Owner)
+		}
+
+		content, err := scan.Config()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(configFile, content, 0o644); err != nil { //nolint:gosec // the config file is not a secret
+			return err
+		}
+
+		logger.Log.Infoln("Wrote the config file:", configFile)
+		if scan.License == "" {
+			logger.Log.Warnln("The license cannot be identified, please set the spdx-id in", configFile)
+		}
+
+		return nil
+	},
+}
+
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+// prompt asks for the value on the standard error, and returns the default value if the answer is empty.
+func prompt(in *bufio.Reader, question, defaultValue string) string {
+	fmt.Fprintf(os.Stderr, "%v [%v]: ", question, defaultValue)
+	answer, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return defaultValue
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return defaultValue
+}
@@ -0,0 +1,48 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/reuse"
+)
+
+var ReuseLintCommand = &cobra.Command{
+	Use:  "lint",
+	Long: "lint command checks that every file carries its copyright and license information, inline, in a .license file, in REUSE.toml or in .reuse/dep5, and that the texts of all the used licenses are in the LICENSES directory.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		report, err := reuse.Lint()
+		if err != nil {
+			return err
+		}
+
+		if !report.Compliant() {
+			logger.Log.Errorln(report.String())
+			return fmt.Errorf("the repository is not compliant with the REUSE specification")
+		}
+
+		logger.Log.Infoln(report.String())
+		logger.Log.Infoln("The repository is compliant with the REUSE specification")
+
+		return nil
+	},
+}
@@ -18,8 +18,11 @@
 package commands
 
 import (
+	"os"
+
 	"github.com/apache/skywalking-eyes/internal/logger"
 	"github.com/apache/skywalking-eyes/pkg/config"
+	"github.com/apache/skywalking-eyes/pkg/header"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -37,27 +40,59 @@ var root = &cobra.Command{
 	Long:          "A full-featured license guard to check and fix license headers and dependencies' licenses",
 	SilenceUsage:  true,
 	SilenceErrors: true,
-	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 		level, err := logrus.ParseLevel(verbosity)
 		if err != nil {
 			return err
 		}
 		logger.Log.SetLevel(level)
+		if writesResultsToStdout(cmd) {
+			// keep the standard output clean for the machine-readable results
+			logger.Log.SetOutput(os.Stderr)
+		}
+
+		if cmd == InitCommand || cmd.Parent() == Configuration {
+			// the config commands inspect (or write) the config file by themselves
+			return nil
+		}
 
-		Config, err = config.NewConfigFromFile(configFile)
+		if cmd.Parent() == Header {
+			// the nested config files only configure the license headers
+			Config, err = config.NewConfigWithNested(configFile)
+		} else {
+			Config, err = config.NewConfigFromFile(configFile)
+		}
 		return err
 	},
 	Version: version,
 }
 
+// writesResultsToStdout returns whether the command writes machine-readable results to the standard output.
+func writesResultsToStdout(cmd *cobra.Command) bool {
+	switch cmd {
+	case CheckCommand:
+		return outputFormat != string(header.FormatText) && outputFile == ""
+	case FixCommand, RemoveCommand, MigrateCommand:
+		return dryRun && patchFile == ""
+	case ConfigPrintCommand, ConfigSchemaCommand:
+		return true
+	}
+	return false
+}
+
 // Execute sets flags to the root command appropriately.
 // This is called by main.main(). It only needs to happen once to the root.
 func Execute() error {
 	root.PersistentFlags().StringVarP(&verbosity, "verbosity", "v", logrus.InfoLevel.String(), "log level (debug, info, warn, error, fatal, panic")
 	root.PersistentFlags().StringVarP(&configFile, "config", "c", ".licenserc.yaml", "the config file")
+	root.PersistentFlags().BoolVar(&config.Lenient, "lenient-config", false, "only warn about the problems found in the config file, instead of failing")
 
 	root.AddCommand(Header)
 	root.AddCommand(Deps)
+	root.AddCommand(Hook)
+	root.AddCommand(Reuse)
+	root.AddCommand(Configuration)
+	root.AddCommand(InitCommand)
 
 	return root.Execute()
 }
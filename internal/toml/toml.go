@@ -0,0 +1,189 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package toml parses the subset of TOML used by the files that license-eye reads, such as REUSE.toml and the Python
+// lock files and project files: the [table] and [[array]] headers, and the key/value pairs, whose values are kept
+// raw, and the arrays, inline tables and multi-line strings can span multiple lines.
+package toml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Table is a table in the TOML file, with its keys in order and their raw values.
+type Table struct {
+	Name string
+	// Array is whether the table is an element of an array of tables, i.e. [[name]].
+	Array  bool
+	Keys   []string
+	Values map[string]string
+	// Lines are the line numbers of the keys, starting from 1.
+	Lines map[string]int
+}
+
+// Parse parses the tables of the TOML content, the key/value pairs before the first table header are in the root
+// table, whose name is empty.
+func Parse(content string) ([]*Table, error) {
+	current := newTable("", false)
+	tables := []*Table{current}
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			array := strings.HasPrefix(line, "[[")
+			current = newTable(strings.TrimSpace(strings.Trim(line, "[]")), array)
+			tables = append(tables, current)
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: unsupported syntax %q", i+1, line)
+		}
+		key, value = strings.Trim(strings.TrimSpace(key), `"'`), strings.TrimSpace(value)
+		current.Lines[key] = i + 1
+		if strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, "'''") {
+			delimiter := value[:3]
+			for !strings.Contains(value[3:], delimiter) && i+1 < len(lines) {
+				i++
+				value += "\n" + lines[i]
+			}
+		}
+		for depth(value) > 0 && i+1 < len(lines) {
+			i++
+			value += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+		current.Keys = append(current.Keys, key)
+		current.Values[key] = value
+	}
+	return tables, nil
+}
+
+func newTable(name string, array bool) *Table {
+	return &Table{Name: name, Array: array, Values: make(map[string]string), Lines: make(map[string]int)}
+}
+
+// String returns the string value of the key, or empty if the key is absent or its value is not a string.
+func (table *Table) String(key string) string {
+	s, n, err := parseString(table.Values[key])
+	if err != nil || n != len(table.Values[key]) {
+		return ""
+	}
+	return s
+}
+
+// Strings returns the string value or the values in the string array of the key.
+func (table *Table) Strings(key string) ([]string, error) {
+	value := table.Values[key]
+	if strings.HasPrefix(value, "[") {
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("unclosed array %q", value)
+		}
+		var values []string
+		rest := strings.TrimSpace(value[1 : len(value)-1])
+		for rest != "" {
+			s, n, err := parseString(rest)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, s)
+			rest = strings.TrimSpace(rest[n:])
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+		}
+		return values, nil
+	}
+
+	s, n, err := parseString(value)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(value[n:]) != "" {
+		return nil, fmt.Errorf("unexpected %q after the string", value[n:])
+	}
+	return []string{s}, nil
+}
+
+// stripComment removes the comment of the line, the # in the strings are kept.
+func stripComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0 && c == quote && (quote == '\'' || i == 0 || line[i-1] != '\\'):
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// depth returns the number of the unclosed arrays and inline tables in the value.
+func depth(value string) int {
+	n := 0
+	var quote rune
+	for i, c := range value {
+		switch {
+		case quote != 0 && c == quote && (quote == '\'' || i == 0 || value[i-1] != '\\'):
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			n++
+		case c == ']' || c == '}':
+			n--
+		}
+	}
+	return n
+}
+
+// parseString parses the leading basic or literal string of the value, and returns the length it takes.
+func parseString(value string) (string, int, error) {
+	if value == "" || (value[0] != '"' && value[0] != '\'') {
+		return "", 0, fmt.Errorf("expected a string, got %q", value)
+	}
+
+	quote := value[0]
+	var sb strings.Builder
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == quote:
+			return sb.String(), i + 1, nil
+		case c == '\\' && quote == '"' && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(value[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unclosed string %q", value)
+}
@@ -18,6 +18,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/apache/skywalking-eyes/assets"
@@ -58,8 +59,10 @@ func (config *V1) Dependencies() *deps.ConfigDeps {
 }
 
 type V2 struct {
-	Header []*header.ConfigHeader `yaml:"header"`
-	Deps   deps.ConfigDeps        `yaml:"dependency"`
+	// Extends are the base configs merged into this one, see ResolveExtends.
+	Extends Extends                `yaml:"extends,omitempty"`
+	Header  []*header.ConfigHeader `yaml:"header"`
+	Deps    deps.ConfigDeps        `yaml:"dependency"`
 }
 
 func ParseV2(filename string, bytes []byte) (*V2, error) {
@@ -94,31 +97,69 @@ type Config interface {
 	Dependencies() *deps.ConfigDeps
 }
 
-func NewConfigFromFile(filename string) (Config, error) {
-	var err error
-	var bytes []byte
+// Lenient makes NewConfigFromFile only warn about the problems found in the config file, see Validate, instead of
+// failing on them.
+var Lenient bool
 
+func NewConfigFromFile(filename string) (Config, error) {
 	// attempt to read configuration from specified file
 	logger.Log.Infoln("Loading configuration from file:", filename)
 
-	if bytes, err = os.ReadFile(filename); err != nil && !os.IsNotExist(err) {
+	bytes, err := ReadConfigFile(filename)
+	if err != nil {
 		return nil, err
 	}
 
-	if os.IsNotExist(err) {
-		logger.Log.Infof("Config file %s does not exist, using the default config", filename)
-
-		if bytes, err = assets.Asset("default-config.yaml"); err != nil {
+	if _, err := os.Stat(filename); err == nil {
+		problems, err := Validate(filename)
+		if err != nil {
 			return nil, err
 		}
+		if Lenient {
+			for _, problem := range problems {
+				logger.Log.Warnln(problem, "(run `license-eye config validate` to check the config file)")
+			}
+		} else if len(problems) > 0 {
+			for _, problem := range problems {
+				logger.Log.Errorln(problem)
+			}
+			return nil, fmt.Errorf("%d problem(s) found in the config file %v, fix them or pass --lenient-config to ignore them", len(problems), filename)
+		}
 	}
 
 	var config Config
-	if config, err = ParseV2(filename, bytes); err == nil {
-		return config, nil
+	if isV1(bytes) {
+		config, err = ParseV1(filename, bytes)
+	} else {
+		config, err = ParseV2(filename, bytes)
 	}
-	if config, err = ParseV1(filename, bytes); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	return config, nil
 }
+
+// isV1 returns whether the config is of V1, that is, it has a single header instead of a list.
+func isV1(bytes []byte) bool {
+	var config struct {
+		Header yaml.Node `yaml:"header"`
+	}
+	return yaml.Unmarshal(bytes, &config) == nil && config.Header.Kind == yaml.MappingNode
+}
+
+// ReadConfigFile reads the content of the config file with its extends resolved,
+// or the default config if the file doesn't exist.
+func ReadConfigFile(filename string) ([]byte, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if os.IsNotExist(err) {
+		logger.Log.Infof("Config file %s does not exist, using the default config", filename)
+
+		return assets.Asset("default-config.yaml")
+	}
+
+	return ResolveExtends(filename, bytes)
+}
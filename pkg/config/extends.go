@@ -0,0 +1,312 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const extendsKey = "extends"
+
+// Extends are the paths of the base configs that a config extends, relative to the config file.
+// It can be either a single path or a list of paths.
+type Extends []string
+
+func (extends *Extends) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*extends = Extends{node.Value}
+		return nil
+	}
+	var paths []string
+	if err := node.Decode(&paths); err != nil {
+		return err
+	}
+	*extends = paths
+	return nil
+}
+
+// ResolveExtends returns the content of the config file with all the base configs in its `extends` merged into it,
+// recursively. The bases are merged in order, and the config itself goes last, the latter ones take precedence:
+//
+//   - the mappings are merged recursively, the scalars and other lists of the latter ones win;
+//   - the `header` entries with the same `paths` are merged, the others are appended;
+//   - the `dependency.licenses` entries with the same name and version are replaced, the latter ones go first so
+//     that they are matched first;
+//   - the `dependency.excludes` entries with the same name and version are replaced, the others are appended;
+//   - `dependency.files`, `paths-ignore` and `protected-headers` of the headers are the union of them.
+//
+// The relative `dependency.files` in the bases are rebased to the directory of the config file.
+// The content is returned unchanged if it doesn't extend any config.
+func ResolveExtends(filename string, bytes []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bytes, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || mappingValue(doc.Content[0], extendsKey) == nil {
+		return bytes, nil
+	}
+
+	root, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := resolveNode(root, doc.Content[0], filepath.Dir(root), []string{root})
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(merged); err != nil {
+		return nil, err
+	}
+	return []byte(sb.String()), nil
+}
+
+// loadExtended loads the base config file, and resolves its own bases.
+func loadExtended(file, rootDir string, chain []string) (*yaml.Node, error) {
+	for _, f := range chain {
+		if f == file {
+			return nil, fmt.Errorf("circular extends: %v", strings.Join(append(chain, file), " -> "))
+		}
+	}
+
+	bytes, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bytes, &doc); err != nil {
+		return nil, fmt.Errorf("%v: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+	return resolveNode(file, doc.Content[0], rootDir, append(chain, file))
+}
+
+// resolveNode merges the bases of the config node into it, and rebases its dependency files to the rootDir.
+func resolveNode(file string, node *yaml.Node, rootDir string, chain []string) (*yaml.Node, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%v: the config must be a mapping", file)
+	}
+
+	normalizeHeaders(node)
+	if dir := filepath.Dir(file); dir != rootDir {
+		rebaseFiles(node, dir, rootDir)
+	}
+
+	extendsNode := mappingValue(node, extendsKey)
+	if extendsNode == nil {
+		return node, nil
+	}
+	var extends Extends
+	if err := extendsNode.Decode(&extends); err != nil {
+		return nil, fmt.Errorf("%v: invalid %v: %w", file, extendsKey, err)
+	}
+	removeMappingValue(node, extendsKey)
+
+	var merged *yaml.Node
+	for _, base := range extends {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(file), base)
+		}
+		baseNode, err := loadExtended(filepath.Clean(base), rootDir, chain)
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeNodes(merged, baseNode, "")
+	}
+	return mergeNodes(merged, node, ""), nil
+}
+
+// normalizeHeaders turns the single header of the V1 config into a list.
+func normalizeHeaders(node *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "header" && node.Content[i+1].Kind == yaml.MappingNode {
+			node.Content[i+1] = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{node.Content[i+1]}}
+		}
+	}
+}
+
+// rebaseFiles makes the relative dependency files in the config of the dir relative to the rootDir.
+func rebaseFiles(node *yaml.Node, dir, rootDir string) {
+	files := mappingValue(mappingValue(node, "dependency"), "files")
+	if files == nil || files.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, f := range files.Content {
+		if f.Kind != yaml.ScalarNode || filepath.IsAbs(f.Value) {
+			continue
+		}
+		abs := filepath.Join(dir, f.Value)
+		if rel, err := filepath.Rel(rootDir, abs); err == nil {
+			f.Value = rel
+		} else {
+			f.Value = abs
+		}
+	}
+}
+
+// mergeNodes merges the override node into the base node by the rules of the path.
+func mergeNodes(base, override *yaml.Node, path string) *yaml.Node {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	switch path {
+	case "header":
+		return mergeSequence(base, override, path, headerKey, false)
+	case "dependency.licenses":
+		return mergeSequence(base, override, path, nameVersionKey, true)
+	case "dependency.excludes":
+		return mergeSequence(base, override, path, nameVersionKey, false)
+	case "dependency.files", "header[].paths-ignore", "header[].protected-headers":
+		return mergeSequence(base, override, path, scalarKey, false)
+	}
+
+	if base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		return override
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: base.Tag, Content: append([]*yaml.Node{}, base.Content...)}
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+		childPath := key.Value
+		if path != "" {
+			childPath = path + "." + key.Value
+		}
+		if j := mappingIndex(merged, key.Value); j >= 0 {
+			merged.Content[j+1] = mergeNodes(merged.Content[j+1], value, childPath)
+		} else {
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+	return merged
+}
+
+// mergeSequence merges the entries of the override sequence at the path with the same keys into the ones of the base
+// sequence, and appends the others, or puts them first if overrideFirst is true.
+func mergeSequence(base, override *yaml.Node, path string, key func(*yaml.Node) string, overrideFirst bool) *yaml.Node {
+	if base.Kind != yaml.SequenceNode || override.Kind != yaml.SequenceNode {
+		return override
+	}
+
+	merged := &yaml.Node{Kind: yaml.SequenceNode, Tag: base.Tag}
+	overridden := make(map[string]*yaml.Node)
+	for _, entry := range override.Content {
+		overridden[key(entry)] = entry
+	}
+
+	var baseEntries []*yaml.Node
+	for _, entry := range base.Content {
+		k := key(entry)
+		o, ok := overridden[k]
+		switch {
+		case !ok:
+			baseEntries = append(baseEntries, entry)
+		case overrideFirst:
+			// replaced by the override entry
+		default:
+			if entry.Kind == yaml.MappingNode {
+				baseEntries = append(baseEntries, mergeNodes(entry, o, path+"[]"))
+			} else {
+				baseEntries = append(baseEntries, o)
+			}
+			delete(overridden, k)
+		}
+	}
+
+	var overrideEntries []*yaml.Node
+	for _, entry := range override.Content {
+		if _, ok := overridden[key(entry)]; ok {
+			overrideEntries = append(overrideEntries, entry)
+		}
+	}
+
+	if overrideFirst {
+		merged.Content = append(overrideEntries, baseEntries...)
+	} else {
+		merged.Content = append(baseEntries, overrideEntries...)
+	}
+	return merged
+}
+
+// headerKey returns the key of the header entry, that is, its sorted paths.
+func headerKey(node *yaml.Node) string {
+	var paths []string
+	if p := mappingValue(node, "paths"); p != nil {
+		_ = p.Decode(&paths)
+	}
+	if len(paths) == 0 {
+		paths = []string{"**"}
+	}
+	sort.Strings(paths)
+	return strings.Join(paths, "\n")
+}
+
+// nameVersionKey returns the key of the dependency entry, that is, its name and version.
+func nameVersionKey(node *yaml.Node) string {
+	var name, version string
+	if n := mappingValue(node, "name"); n != nil {
+		name = n.Value
+	}
+	if v := mappingValue(node, "version"); v != nil {
+		version = v.Value
+	}
+	return name + "@" + version
+}
+
+func scalarKey(node *yaml.Node) string {
+	return node.Value
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(node, key); i >= 0 {
+		return node.Content[i+1]
+	}
+	return nil
+}
+
+func removeMappingValue(node *yaml.Node, key string) {
+	if i := mappingIndex(node, key); i >= 0 {
+		node.Content = append(node.Content[:i], node.Content[i+2:]...)
+	}
+}
@@ -0,0 +1,152 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResolveExtends(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"org/base.yaml": `header:
+  license:
+    spdx-id: Apache-2.0
+    copyright-owner: Org
+  paths-ignore:
+    - LICENSE
+dependency:
+  files:
+    - go.mod
+  licenses:
+    - name: github.com/org/*
+      license: Apache-2.0
+    - name: github.com/foo/bar
+      license: MIT
+  excludes:
+    - name: github.com/org/internal
+`,
+		"org/policy.yaml": `extends: base.yaml
+dependency:
+  require_osi_approved: true
+`,
+		"repo/.licenserc.yaml": `extends:
+  - ../org/policy.yaml
+header:
+  - license:
+      copyright-owner: Repo
+    paths-ignore:
+      - NOTICE
+  - license:
+      spdx-id: MIT
+    paths:
+      - third-party/**
+dependency:
+  files:
+    - package.json
+  licenses:
+    - name: github.com/foo/bar
+      license: BSD-3-Clause
+  excludes:
+    - name: github.com/org/internal
+      recursive: true
+`,
+		"loop/a.yaml": "extends: b.yaml\n",
+		"loop/b.yaml": "extends: a.yaml\n",
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+
+	filename := filepath.Join(dir, "repo", ".licenserc.yaml")
+	bytes, err := ReadConfigFile(filename)
+	require.NoError(t, err)
+
+	var config V2
+	require.NoError(t, yaml.Unmarshal(bytes, &config))
+	require.Empty(t, config.Extends)
+
+	require.Len(t, config.Header, 2)
+	require.Equal(t, "Apache-2.0", config.Header[0].License.SpdxID)
+	require.Equal(t, "Repo", config.Header[0].License.CopyrightOwner)
+	require.Equal(t, []string{"LICENSE", "NOTICE"}, config.Header[0].PathsIgnore)
+	require.Equal(t, "MIT", config.Header[1].License.SpdxID)
+	require.Equal(t, []string{"third-party/**"}, config.Header[1].Paths)
+
+	deps := config.Deps
+	require.Equal(t, []string{filepath.Join("..", "org", "go.mod"), "package.json"}, deps.Files)
+	require.True(t, deps.RequireOSIApproved)
+	require.Len(t, deps.Licenses, 2)
+	require.Equal(t, "BSD-3-Clause", deps.Licenses[0].License)
+	require.Equal(t, "github.com/org/*", deps.Licenses[1].Name)
+	require.Len(t, deps.Excludes, 1)
+	require.True(t, deps.Excludes[0].Recursive)
+
+	_, err = ReadConfigFile(filepath.Join(dir, "loop", "a.yaml"))
+	require.ErrorContains(t, err, "circular extends")
+}
+
+func TestResolveExtendsWithoutExtends(t *testing.T) {
+	content := []byte("header:\n  license:\n    spdx-id: Apache-2.0\n")
+	resolved, err := ResolveExtends(".licenserc.yaml", content)
+	require.NoError(t, err)
+	require.Equal(t, content, resolved)
+}
+
+func TestMergeSequenceEntries(t *testing.T) {
+	var base, override yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`header:
+  - paths-ignore: [a]
+dependency:
+  excludes:
+    - name: foo
+      paths-ignore: [a]
+`), &base))
+	require.NoError(t, yaml.Unmarshal([]byte(`header:
+  - paths-ignore: [b]
+dependency:
+  excludes:
+    - name: foo
+      paths-ignore: [b]
+`), &override))
+
+	merged, err := yaml.Marshal(mergeNodes(base.Content[0], override.Content[0], ""))
+	require.NoError(t, err)
+
+	var config struct {
+		Header []struct {
+			PathsIgnore []string `yaml:"paths-ignore"`
+		} `yaml:"header"`
+		Dependency struct {
+			Excludes []struct {
+				PathsIgnore []string `yaml:"paths-ignore"`
+			} `yaml:"excludes"`
+		} `yaml:"dependency"`
+	}
+	require.NoError(t, yaml.Unmarshal(merged, &config))
+	// the rules of the header entries don't apply to the entries of other sequences
+	require.Equal(t, []string{"a", "b"}, config.Header[0].PathsIgnore)
+	require.Equal(t, []string{"b"}, config.Dependency.Excludes[0].PathsIgnore)
+}
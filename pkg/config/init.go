@@ -0,0 +1,331 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	"github.com/apache/skywalking-eyes/pkg/header"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+const (
+	// scanHeadBytes is the number of leading bytes of a file that are scanned.
+	scanHeadBytes = 1024
+	// scanMaxHeaders is the maximum number of files whose headers are scanned for the copyright owner.
+	scanMaxHeaders = 500
+	// identifyThreshold is the minimum coverage to identify the license of the project.
+	identifyThreshold = 75
+)
+
+var (
+	// LicenseFiles are the files that hold the license of the project, in the order of precedence.
+	LicenseFiles = []string{"LICENSE", "LICENSE.txt", "LICENSE.md", "LICENCE", "COPYING", "COPYING.txt"}
+	// ManifestFiles are the patterns of the dependency manifest files whose dependencies can be resolved, grouped by
+	// the package managers in the order of precedence: only the first one of a group is listed for a directory, e.g.
+	// package-lock.json instead of package.json, so the dependencies are not resolved twice.
+	ManifestFiles = [][]string{
+		{"go.mod"},
+		{"package-lock.json", "yarn.lock", "pnpm-lock.yaml", "package.json"},
+		{"pom.xml"},
+		{"build.gradle", "build.gradle.kts"},
+		{"Cargo.toml"},
+		{"Gemfile.lock"},
+		{"poetry.lock", "uv.lock", "Pipfile.lock", "requirements.txt"},
+		{"composer.lock", "composer.json"},
+		{"packages.lock.json", "*.csproj"},
+	}
+
+	lockFiles = map[string]bool{
+		"go.sum": true, "package-lock.json": true, "yarn.lock": true, "pnpm-lock.yaml": true, "Cargo.lock": true,
+		"Gemfile.lock": true, "poetry.lock": true, "composer.lock": true, "packages.lock.json": true,
+	}
+	skippedDirs = map[string]bool{"node_modules": true, "vendor": true, "testdata": true}
+
+	copyrightNotice = regexp.MustCompile(`(?im)^\W*(?:SPDX-FileCopyrightText:\s*)?copyright\s+(?:\(c\)\s*|©\s*)?\d{4}(?:\s*[-,]\s*\d{4})*,?\s+(?:by\s+)?(.+?)\s*(?:\*/|-->)?\s*$`)
+	asfNotice       = regexp.MustCompile(`Licensed to the Apache Software Foundation \(ASF\)`)
+	allRights       = regexp.MustCompile(`(?i)[.,]?\s*all rights reserved\.?$`)
+	generatedMarker = regexp.MustCompile(`(?i)code generated .* do not edit|@generated|auto-generated|autogenerated`)
+)
+
+// Scan is the result of scanning the repository in the current directory, to initialize the config file.
+type Scan struct {
+	License     string
+	Owner       string
+	Manifests   []string
+	PathsIgnore []string
+}
+
+// ScanRepo scans the repository in the current directory: it identifies the license of the project from the
+// license file, finds the copyright owner from the existing license headers or the git history, lists the
+// dependency manifest files, and proposes the paths-ignore for the generated files and the files that cannot
+// have license headers.
+func ScanRepo() (*Scan, error) {
+	files, err := header.ListFiles(&header.ConfigHeader{Paths: []string{"**"}})
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &Scan{License: identifyLicense()}
+	owners := make(map[string]int)
+	ignores := make(map[string]bool)
+	scanned := 0
+	for _, file := range files {
+		file = filepath.ToSlash(filepath.Clean(file))
+		if skipped(file) {
+			continue
+		}
+
+		if group, _ := manifestOf(file); group >= 0 {
+			scan.Manifests = append(scan.Manifests, file)
+		}
+
+		head, err := readHead(file)
+		if err != nil {
+			return nil, err
+		}
+		if ignore := proposeIgnore(file, head); ignore != "" {
+			ignores[ignore] = true
+			continue
+		}
+		if scanned < scanMaxHeaders {
+			scanned++
+			if owner := ownerOf(head); owner != "" {
+				owners[owner]++
+			}
+		}
+	}
+
+	scan.Owner = mostFrequent(owners)
+	if scan.Owner == "" {
+		scan.Owner = gitOwner()
+	}
+	for ignore := range ignores {
+		scan.PathsIgnore = append(scan.PathsIgnore, ignore)
+	}
+	sort.Strings(scan.PathsIgnore)
+	scan.Manifests = selectManifests(scan.Manifests)
+	sort.Strings(scan.Manifests)
+
+	return scan, nil
+}
+
+// manifestOf returns the group in ManifestFiles of the manifest file and its precedence in the group, or -1 if the
+// file is not a manifest file.
+func manifestOf(file string) (group, precedence int) {
+	base := path.Base(file)
+	for i, patterns := range ManifestFiles {
+		for j, pattern := range patterns {
+			if matched, _ := path.Match(pattern, base); matched {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
+
+// selectManifests selects the manifest file of the highest precedence among the ones of the same group in the same
+// directory.
+func selectManifests(manifests []string) []string {
+	type key struct {
+		dir   string
+		group int
+	}
+	selected := make(map[key]string)
+	var keys []key
+	for _, file := range manifests {
+		group, precedence := manifestOf(file)
+		k := key{path.Dir(file), group}
+		if current, ok := selected[k]; !ok {
+			keys = append(keys, k)
+			selected[k] = file
+		} else if _, p := manifestOf(current); precedence < p {
+			selected[k] = file
+		}
+	}
+
+	result := make([]string, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, selected[k])
+	}
+	return result
+}
+
+// identifyLicense identifies the license of the project from the license file in the current directory.
+func identifyLicense() string {
+	for _, file := range LicenseFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		id, err := lcs.Identify(string(content), identifyThreshold)
+		if err != nil {
+			logger.Log.Warnf("Failed to identify the license in %v: %v", file, err)
+			return ""
+		}
+		if ids := strings.Split(id, " and "); len(ids) > 1 {
+			logger.Log.Warnf("Multiple licenses are identified in %v: %v, using the first one", file, id)
+			id = ids[0]
+		}
+		return id
+	}
+	return ""
+}
+
+func skipped(file string) bool {
+	for _, dir := range strings.Split(filepath.Dir(file), "/") {
+		if skippedDirs[dir] {
+			return true
+		}
+	}
+	return file == ".git" || strings.HasPrefix(file, ".git/")
+}
+
+func readHead(file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, scanHeadBytes)
+	n, _ := f.Read(head)
+	return head[:n], nil
+}
+
+// proposeIgnore returns the paths-ignore entry for the file if it's a lock file, a generated file, a license file,
+// a binary file or a file that cannot have comments, otherwise an empty string.
+func proposeIgnore(file string, head []byte) string {
+	base, ext := filepath.Base(file), filepath.Ext(file)
+	byExt := func() string {
+		if ext == "" || ext == base {
+			return file
+		}
+		return "**/*" + ext
+	}
+
+	for _, license := range LicenseFiles {
+		if base == license || base == "NOTICE" {
+			return file
+		}
+	}
+	switch {
+	case lockFiles[base]:
+		return "**/" + base
+	case generatedMarker.Match(head):
+		return file
+	case !strings.HasPrefix(http.DetectContentType(head), "text/"):
+		return byExt()
+	case comments.FileCommentStyle(file) == nil:
+		return byExt()
+	}
+	return ""
+}
+
+// ownerOf returns the copyright owner in the license header of the file head.
+func ownerOf(head []byte) string {
+	if asfNotice.Match(head) {
+		return "Apache Software Foundation"
+	}
+	m := copyrightNotice.FindSubmatch(head)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(allRights.ReplaceAllString(string(m[1]), ""))
+}
+
+func mostFrequent(counts map[string]int) string {
+	var most string
+	for value, count := range counts {
+		if count > counts[most] || (count == counts[most] && value < most) {
+			most = value
+		}
+	}
+	return most
+}
+
+// gitOwner returns the author of the first commit of the repository in the current directory.
+func gitOwner() string {
+	repo, err := git.PlainOpen("./")
+	if err != nil {
+		return ""
+	}
+	commits, err := repo.Log(&git.LogOptions{Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return ""
+	}
+	var owner string
+	_ = commits.ForEach(func(c *object.Commit) error {
+		owner = c.Author.Name // the commits are from the latest, so the last one wins
+		return nil
+	})
+	return owner
+}
+
+var configTemplate = template.Must(template.New("config").Parse(`header:
+  - license:
+{{- if .License }}
+      spdx-id: {{ .License }}
+{{- else }}
+      # the license cannot be identified, set the spdx-id or the content of the license header
+      spdx-id:
+{{- end }}
+{{- if .Owner }}
+      copyright-owner: {{ printf "%q" .Owner }}
+{{- end }}
+    paths:
+      - '**'
+{{- if .PathsIgnore }}
+    paths-ignore:
+{{- range .PathsIgnore }}
+      - {{ printf "%q" . }}
+{{- end }}
+{{- end }}
+    comment: on-failure
+{{- if .Manifests }}
+
+dependency:
+  files:
+{{- range .Manifests }}
+    - {{ printf "%q" . }}
+{{- end }}
+{{- end }}
+`))
+
+// Config returns the content of the config file of the scan result.
+func (scan *Scan) Config() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := configTemplate.Execute(&buf, scan); err != nil {
+		return nil, fmt.Errorf("failed to generate the config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
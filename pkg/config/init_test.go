@@ -0,0 +1,100 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+func TestScanRepo(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	mit, err := lcs.GetLicenseContent("MIT")
+	require.NoError(t, err)
+	files := map[string]string{
+		"LICENSE":                         mit,
+		"main.go":                         "// Copyright (c) 2021-2024 Acme Inc. All rights reserved.\n\npackage main\n",
+		"util.go":                         "// Copyright 2022 Acme Inc.\n\npackage main\n",
+		"other.go":                        "// Copyright 2022 Someone\n\npackage main\n",
+		"api.pb.go":                       "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage main\n",
+		"logo.png":                        "\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR",
+		"go.mod":                          "module example.com/acme\n",
+		"go.sum":                          "",
+		"web/package.json":                "{}\n",
+		"web/node_modules/x/package.json": "{}\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	}
+
+	scan, err := ScanRepo()
+	require.NoError(t, err)
+	require.Equal(t, "MIT", scan.License)
+	require.Equal(t, "Acme Inc", scan.Owner)
+	require.Equal(t, []string{"go.mod", "web/package.json"}, scan.Manifests)
+	require.Equal(t, []string{"**/*.json", "**/*.png", "**/go.sum", "LICENSE", "api.pb.go"}, scan.PathsIgnore)
+
+	content, err := scan.Config()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(".licenserc.yaml", content, 0o644))
+
+	problems, err := Validate(".licenserc.yaml")
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	config, err := NewConfigFromFile(".licenserc.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "MIT", config.Headers()[0].License.SpdxID)
+	require.Len(t, config.Dependencies().Files, 2)
+}
+
+func TestScanRepoManifests(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	for _, name := range []string{
+		"go.mod",
+		"web/package.json", "web/package-lock.json",
+		"php/composer.json", "php/composer.lock",
+		"py/requirements.txt", "py/poetry.lock",
+		"jvm/build.gradle", "jvm/build.gradle.kts", "jvm/app/build.gradle.kts",
+		"dotnet/App.csproj",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, nil, 0o644))
+	}
+
+	scan, err := ScanRepo()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"dotnet/App.csproj", "go.mod", "jvm/app/build.gradle.kts", "jvm/build.gradle", "php/composer.lock",
+		"py/poetry.lock", "web/package-lock.json",
+	}, scan.Manifests)
+}
@@ -0,0 +1,300 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	"github.com/apache/skywalking-eyes/pkg/header"
+
+	"github.com/bmatcuk/doublestar/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// scope is the directory that a config file applies to, and the headers in the config file.
+type scope struct {
+	dir     string
+	headers []*header.ConfigHeader
+}
+
+// NewConfigWithNested loads the config file, and the config files of the same name in the subdirectories, the headers
+// in a nested config file apply to the subtree of its directory, instead of the ones in the config files of the parent
+// directories. The paths and paths-ignore in a nested config file are relative to its directory, and the options that
+// are not set in a nested header are inherited from the parent header that covers the directory.
+// Only the header section of the nested config files is used.
+func NewConfigWithNested(filename string) (Config, error) {
+	config, err := NewConfigFromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, h := range config.Headers() {
+		paths = append(paths, h.Paths...)
+	}
+	files, err := nestedConfigFiles(filename, paths)
+	if err != nil || len(files) == 0 {
+		return config, err
+	}
+
+	scopes := []*scope{{dir: ".", headers: config.Headers()}}
+	for _, file := range files {
+		logger.Log.Infoln("Loading nested configuration from file:", file)
+
+		bytes, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if bytes, err = ResolveExtends(file, bytes); err != nil {
+			return nil, err
+		}
+		headers, err := readHeaders(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", file, err)
+		}
+		if len(headers) == 0 {
+			continue // the headers of the parent directory apply
+		}
+
+		dir := filepath.ToSlash(filepath.Dir(file))
+		parent := closestScope(scopes, dir)
+		inherited := parent.covering(dir)
+		for _, h := range headers {
+			if len(h.Paths) == 0 {
+				h.Paths = []string{"**"}
+			}
+			h.Paths = rebase(dir, h.Paths)
+			h.PathsIgnore = rebase(dir, h.PathsIgnore)
+			inherit(h, inherited)
+			if err := h.Finalize(); err != nil {
+				return nil, fmt.Errorf("%v: %w", file, err)
+			}
+		}
+		// the closest config wins, the files in the subtree are no longer checked against the parent headers
+		for _, h := range parent.headers {
+			h.PathsIgnore = append(h.PathsIgnore, dir+"/**")
+		}
+		scopes = append(scopes, &scope{dir: dir, headers: headers})
+	}
+
+	merged := &V2{Deps: *config.Dependencies()}
+	for _, s := range scopes {
+		merged.Header = append(merged.Header, s.headers...)
+	}
+	return merged, nil
+}
+
+// nestedConfigFiles returns the config files of the same name as the root config file in the subdirectories
+// under the paths, the parent directories go before the subdirectories.
+func nestedConfigFiles(filename string, paths []string) ([]string, error) {
+	root, name := filepath.Clean(filename), filepath.Base(filename)
+
+	patterns := nestedConfigPatterns(paths, name)
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	files, err := header.ListFiles(&header.ConfigHeader{Paths: patterns})
+	if err != nil {
+		return nil, err
+	}
+
+	var nested []string
+	for _, file := range files {
+		file = filepath.Clean(file)
+		if filepath.Base(file) != name || file == root || filepath.Dir(file) == "." {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matched, _ := doublestar.Match(pattern, filepath.ToSlash(file)); matched {
+				nested = append(nested, file)
+				break
+			}
+		}
+	}
+	sort.Slice(nested, func(i, j int) bool {
+		di, dj := strings.Count(filepath.ToSlash(nested[i]), "/"), strings.Count(filepath.ToSlash(nested[j]), "/")
+		if di != dj {
+			return di < dj
+		}
+		return nested[i] < nested[j]
+	})
+	return nested, nil
+}
+
+// nestedConfigPatterns returns the patterns of the config files named name, that apply to the files matching the
+// path patterns, which are the config files in the directories that the patterns cover, such as
+// "src/**/.licenserc.yaml" for "src/**/*.go", and the ones in their parent directories, except the root one.
+func nestedConfigPatterns(paths []string, name string) []string {
+	var patterns []string
+	add := func(pattern string) {
+		if !slices.Contains(patterns, pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	for _, p := range paths {
+		p = strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(p), "./"), "/")
+
+		var static []string
+		for _, segment := range strings.Split(p, "/") {
+			if segment == "." || segment == "" {
+				continue
+			}
+			if strings.ContainsAny(segment, "*?[{") {
+				break
+			}
+			static = append(static, segment)
+		}
+		dir, recursive := strings.Join(static, "/"), true
+		if dir == p && dir != "" {
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				// a single file, only the config files in its directory and the parent ones apply
+				dir, recursive = path.Dir(dir), false
+			}
+		}
+
+		parents := strings.Split(dir, "/")
+		if !recursive {
+			parents = append(parents, "")
+		}
+		for i := 1; i < len(parents); i++ {
+			if parent := strings.Join(parents[:i], "/"); parent != "." && parent != "" {
+				add(path.Join(parent, name))
+			}
+		}
+		if recursive {
+			add(path.Join(dir, "**", name))
+		}
+	}
+	return patterns
+}
+
+// readHeaders reads the headers in the config file of either version, without finalizing them.
+func readHeaders(bytes []byte) ([]*header.ConfigHeader, error) {
+	var v2 struct {
+		Header []*header.ConfigHeader `yaml:"header"`
+	}
+	if err := yaml.Unmarshal(bytes, &v2); err == nil {
+		return v2.Header, nil
+	}
+
+	var v1 struct {
+		Header *header.ConfigHeader `yaml:"header"`
+	}
+	if err := yaml.Unmarshal(bytes, &v1); err != nil {
+		return nil, err
+	}
+	if v1.Header == nil {
+		return nil, nil
+	}
+	return []*header.ConfigHeader{v1.Header}, nil
+}
+
+// closestScope returns the scope of the closest parent directory of the dir.
+func closestScope(scopes []*scope, dir string) *scope {
+	closest := scopes[0]
+	for _, s := range scopes[1:] {
+		if strings.HasPrefix(dir+"/", s.dir+"/") && len(s.dir) > len(closest.dir) {
+			closest = s
+		}
+	}
+	return closest
+}
+
+// covering returns the header that covers the dir, or the first header if none of them does.
+func (s *scope) covering(dir string) *header.ConfigHeader {
+	for _, h := range s.headers {
+		if ignored, err := h.ShouldIgnore(dir); err == nil && !ignored {
+			return h
+		}
+	}
+	if len(s.headers) > 0 {
+		return s.headers[0]
+	}
+	return nil
+}
+
+// rebase makes the path patterns relative to the dir relative to the root directory.
+func rebase(dir string, patterns []string) []string {
+	rebased := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		switch pattern = strings.TrimPrefix(pattern, "./"); pattern {
+		case ".", "":
+			rebased = append(rebased, dir)
+		default:
+			rebased = append(rebased, path.Join(dir, pattern))
+		}
+	}
+	return rebased
+}
+
+// inherit sets the options that are not set in the header to the ones of the parent header.
+func inherit(h, parent *header.ConfigHeader) {
+	if parent == nil {
+		return
+	}
+
+	license, parentLicense := &h.License, &parent.License
+	if license.SpdxID == "" && license.Content == "" && license.Pattern == "" {
+		license.SpdxID, license.Content, license.Pattern = parentLicense.SpdxID, parentLicense.Content, parentLicense.Pattern
+	}
+	if license.CopyrightOwner == "" {
+		license.CopyrightOwner = parentLicense.CopyrightOwner
+	}
+	if license.CopyrightYear == "" {
+		license.CopyrightYear = parentLicense.CopyrightYear
+	}
+	if license.SoftwareName == "" {
+		license.SoftwareName = parentLicense.SoftwareName
+	}
+	if license.CopyrightYearPolicy == "" {
+		license.CopyrightYearPolicy = parentLicense.CopyrightYearPolicy
+	}
+	if license.Style == "" {
+		license.Style = parentLicense.Style
+	}
+
+	h.PathsIgnore = append(append([]string{}, parent.PathsIgnore...), h.PathsIgnore...)
+	h.ProtectedHeaders = append(append([]string{}, parent.ProtectedHeaders...), h.ProtectedHeaders...)
+	if h.Comment == "" {
+		h.Comment = parent.Comment
+	}
+	if h.LicenseLocationThreshold <= 0 {
+		h.LicenseLocationThreshold = parent.LicenseLocationThreshold
+	}
+	if h.Jobs <= 0 {
+		h.Jobs = parent.Jobs
+	}
+	h.Sidecar = h.Sidecar || parent.Sidecar
+	for language, style := range parent.Languages {
+		if _, ok := h.Languages[language]; !ok {
+			if h.Languages == nil {
+				h.Languages = make(map[string]comments.Language)
+			}
+			h.Languages[language] = style
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigWithNested(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	files := map[string]string{
+		".licenserc.yaml": `header:
+  license:
+    spdx-id: Apache-2.0
+    copyright-owner: Acme
+  paths-ignore:
+    - '**/*.md'
+  comment: on-failure
+`,
+		"vendor/lib/.licenserc.yaml": `header:
+  - license:
+      spdx-id: MIT
+      copyright-owner: Someone
+    paths-ignore:
+      - generated
+`,
+		"vendor/lib/sub/.licenserc.yaml": `header:
+  - paths:
+      - '**/*.go'
+`,
+		"docs/.licenserc.yaml": `dependency:
+  files:
+    - go.mod
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	}
+
+	config, err := NewConfigWithNested(".licenserc.yaml")
+	require.NoError(t, err)
+
+	headers := config.Headers()
+	require.Len(t, headers, 3)
+
+	root, vendor, sub := headers[0], headers[1], headers[2]
+	require.Equal(t, []string{"**/*.md", "vendor/lib/**"}, root.PathsIgnore)
+
+	require.Equal(t, "MIT", vendor.License.SpdxID)
+	require.Equal(t, "Someone", vendor.License.CopyrightOwner)
+	require.Equal(t, []string{"vendor/lib/**"}, vendor.Paths)
+	require.Equal(t, []string{"**/*.md", "vendor/lib/generated", "vendor/lib/sub/**"}, vendor.PathsIgnore)
+	require.Equal(t, root.Comment, vendor.Comment)
+
+	require.Equal(t, "MIT", sub.License.SpdxID)
+	require.Equal(t, []string{"vendor/lib/sub/**/*.go"}, sub.Paths)
+
+	for file, expected := range map[string]int{
+		"main.go":                     0,
+		"README.md":                   -1,
+		"vendor/lib/lib.go":           1,
+		"vendor/lib/generated/gen.go": -1,
+		"vendor/lib/sub/sub.go":       2,
+		"docs/doc.go":                 0,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+		matched := -1
+		for i, h := range headers {
+			if ignored, err := h.ShouldIgnore(file); err == nil && !ignored {
+				require.Equal(t, -1, matched, "%v is covered by multiple headers", file)
+				matched = i
+			}
+		}
+		require.Equal(t, expected, matched, file)
+	}
+}
+
+func TestNestedConfigUnderPaths(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	files := map[string]string{
+		".licenserc.yaml": `header:
+  license:
+    spdx-id: Apache-2.0
+    copyright-owner: Acme
+  paths:
+    - 'src/**/*.go'
+    - 'LICENSE'
+`,
+		"src/lib/.licenserc.yaml": `header:
+  - license:
+      spdx-id: MIT
+`,
+		"docs/.licenserc.yaml": `header:
+  - license:
+      spdx-id: MIT
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	}
+
+	require.Equal(t, []string{"src/**/.licenserc.yaml"},
+		nestedConfigPatterns([]string{"src/**/*.go", "./src/**", "LICENSE"}, ".licenserc.yaml"))
+	require.Equal(t, []string{"a/.licenserc.yaml", "a/b/**/.licenserc.yaml", "x/.licenserc.yaml", "x/y/.licenserc.yaml"},
+		nestedConfigPatterns([]string{"a/b/**", "x/y/z.go"}, ".licenserc.yaml"))
+	require.Equal(t, []string{"**/.licenserc.yaml"}, nestedConfigPatterns([]string{"**"}, ".licenserc.yaml"))
+
+	config, err := NewConfigWithNested(".licenserc.yaml")
+	require.NoError(t, err)
+
+	headers := config.Headers()
+	require.Len(t, headers, 2)
+	require.Equal(t, []string{"src/lib/**"}, headers[1].Paths)
+}
@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/header"
+)
+
+// SchemaFile is the published JSON Schema of the config file in the assets, generated by Schema.
+const SchemaFile = "licenserc.schema.json"
+
+// enums are the valid values of the string types that are enumerations.
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(header.CommentOption("")): {string(header.Always), string(header.Never), string(header.OnFailure)},
+	reflect.TypeOf(header.CopyrightYearPolicy("")): {
+		string(header.YearPolicyAny), string(header.YearPolicyCreationYear),
+		string(header.YearPolicyRangeToCurrent), string(header.YearPolicyLastModified),
+	},
+	reflect.TypeOf(header.LicenseStyle("")): {string(header.LicenseStyleSPDXShort)},
+}
+
+var extendsType = reflect.TypeOf(Extends{})
+
+// yamlField is a field of a struct that is decoded from the config file.
+type yamlField struct {
+	name string
+	typ  reflect.Type
+}
+
+// yamlFields returns the fields of the struct that can be set in the config file, in their declaration order.
+func yamlFields(t reflect.Type) []yamlField {
+	var fields []yamlField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, yamlField{name: name, typ: f.Type})
+	}
+	return fields
+}
+
+// Schema returns the JSON Schema of the config file, generated from the config structs.
+func Schema() ([]byte, error) {
+	definitions := make(map[string]any)
+	root := schemaOf(reflect.TypeOf(V2{}), definitions)
+	root["$schema"] = "http://json-schema.org/draft-07/schema#"
+	root["title"] = "License-Eye configuration"
+	root["definitions"] = definitions
+
+	// the V1 config has a single header instead of a list
+	properties := root["properties"].(map[string]any)
+	properties["header"] = map[string]any{"oneOf": []any{properties["header"], definitionRef("ConfigHeader")}}
+
+	bytes, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
+
+// schemaOf returns the schema of the type, the named struct types are put into the definitions and referred.
+func schemaOf(t reflect.Type, definitions map[string]any) map[string]any {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == extendsType {
+		return map[string]any{"oneOf": []any{
+			map[string]any{"type": "string"},
+			map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		}}
+	}
+	if values, ok := enums[t]; ok {
+		return map[string]any{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem(), definitions)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem(), definitions)}
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for _, f := range yamlFields(t) {
+			properties[f.name] = schemaOf(f.typ, definitions)
+		}
+		object := map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+		if t == reflect.TypeOf(V2{}) {
+			return object
+		}
+		if _, ok := definitions[t.Name()]; !ok {
+			definitions[t.Name()] = object
+		}
+		return definitionRef(t.Name())
+	}
+	return map[string]any{}
+}
+
+func definitionRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
@@ -0,0 +1,260 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// Problem is a problem found in the config file, at the position of the node.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p *Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+type validator struct {
+	file     string
+	chain    []string
+	problems []*Problem
+}
+
+// Validate validates the config file, and the base configs it extends, strictly against the config structs.
+// It reports the unknown keys, the values of wrong types, the unknown SPDX license IDs, the invalid globs of
+// the paths, and the invalid regular expressions. An error is returned only if the file cannot be read or parsed.
+func Validate(filename string) ([]*Problem, error) {
+	return validateFile(filename, nil)
+}
+
+func validateFile(filename string, chain []string) ([]*Problem, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(bytes, &doc); err != nil {
+		return nil, fmt.Errorf("%v: %w", filename, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	v := &validator{file: filename, chain: append(chain, filepath.Clean(filename))}
+	v.validate(doc.Content[0], reflect.TypeOf(V2{}), "")
+	return v.problems, nil
+}
+
+func (v *validator) report(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, &Problem{
+		File:    v.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// validate validates the node against the type, path is the path of the node in the config, such as header[].paths.
+func (v *validator) validate(node *yaml.Node, t reflect.Type, path string) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" {
+		return
+	}
+
+	switch {
+	case path == "header" && node.Kind == yaml.MappingNode: // the single header of the V1 config
+		v.validate(node, t.Elem(), "header[]")
+		return
+	case t == extendsType:
+		v.validateExtends(node)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		v.validateStruct(node, t, path)
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.report(node, "%v must be a list", path)
+			return
+		}
+		for _, item := range node.Content {
+			v.validate(item, t.Elem(), path+"[]")
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.report(node, "%v must be a mapping", path)
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.validate(node.Content[i+1], t.Elem(), path+"."+node.Content[i].Value)
+		}
+	default:
+		v.validateScalar(node, t, path)
+	}
+}
+
+func (v *validator) validateStruct(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind != yaml.MappingNode {
+		v.report(node, "%v must be a mapping", strings.TrimPrefix(path, "."))
+		return
+	}
+
+	fields := yamlFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		var field *yamlField
+		for j := range fields {
+			if fields[j].name == key.Value {
+				field = &fields[j]
+			}
+		}
+		if field == nil {
+			v.reportUnknownKey(key, fields)
+			continue
+		}
+		childPath := key.Value
+		if path != "" {
+			childPath = path + "." + key.Value
+		}
+		v.validate(value, field.typ, childPath)
+	}
+}
+
+func (v *validator) reportUnknownKey(key *yaml.Node, fields []yamlField) {
+	for _, f := range fields {
+		if strings.ReplaceAll(f.name, "_", "-") == strings.ReplaceAll(key.Value, "_", "-") {
+			v.report(key, "unknown key %q, did you mean %q?", key.Value, f.name)
+			return
+		}
+	}
+	v.report(key, "unknown key %q", key.Value)
+}
+
+func (v *validator) validateScalar(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind != yaml.ScalarNode {
+		v.report(node, "%v must be a scalar value", path)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if _, err := strconv.ParseBool(node.Value); err != nil || node.Tag != "!!bool" {
+			v.report(node, "%v must be a boolean, got %q", path, node.Value)
+		}
+		return
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, err := strconv.Atoi(node.Value); err != nil {
+			v.report(node, "%v must be an integer, got %q", path, node.Value)
+		}
+		return
+	}
+
+	if values, ok := enums[t]; ok && node.Value != "" {
+		valid := false
+		for _, value := range values {
+			valid = valid || value == node.Value
+		}
+		if !valid {
+			v.report(node, "unknown %v %q, expected one of %q", path, node.Value, values)
+		}
+		return
+	}
+
+	switch path {
+	case "header[].license.spdx-id", "dependency.licenses[].license":
+		if _, err := lcs.ParseExpression(node.Value); err != nil {
+			v.report(node, "%v", err)
+		}
+	case "header[].license.pattern", "header[].protected-headers[]":
+		if _, err := regexp.Compile(node.Value); err != nil {
+			v.report(node, "invalid regular expression in %v: %v", path, err)
+		}
+	case "header[].paths[]", "header[].paths-ignore[]":
+		if err := validateGlob(node.Value); err != nil {
+			v.report(node, "invalid glob %q in %v: %v", node.Value, path, err)
+		}
+	case "dependency.licenses[].name", "dependency.excludes[].name":
+		if _, err := filepath.Match(node.Value, ""); err != nil {
+			v.report(node, "invalid pattern %q in %v: %v", node.Value, path, err)
+		}
+	}
+}
+
+// validateExtends validates the base configs as well.
+func (v *validator) validateExtends(node *yaml.Node) {
+	var extends Extends
+	if err := node.Decode(&extends); err != nil {
+		v.report(node, "extends must be a path or a list of paths")
+		return
+	}
+	for _, base := range extends {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(v.file), base)
+		}
+		base = filepath.Clean(base)
+		for _, f := range v.chain {
+			if f == base {
+				v.report(node, "circular extends: %v", strings.Join(append(v.chain, base), " -> "))
+				return
+			}
+		}
+		problems, err := validateFile(base, v.chain)
+		if err != nil {
+			v.report(node, "cannot load the base config: %v", err)
+			continue
+		}
+		v.problems = append(v.problems, problems...)
+	}
+}
+
+// validateGlob validates the syntax of the doublestar glob.
+func validateGlob(pattern string) error {
+	if strings.Count(pattern, "{") != strings.Count(pattern, "}") {
+		return fmt.Errorf("unbalanced braces")
+	}
+	for _, part := range strings.Split(pattern, "/") {
+		if part == "**" {
+			continue
+		}
+		if _, err := path.Match(part, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
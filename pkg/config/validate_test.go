@@ -0,0 +1,108 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/skywalking-eyes/assets"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("header:\n  licence:\n    spdx-id: MIT\n"), 0o644))
+
+	file := filepath.Join(dir, ".licenserc.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`extends: base.yaml
+header:
+  - license:
+      spdx_id: Apache-2.0
+      pattern: '(unclosed'
+    paths_ignore:
+      - dist
+    paths:
+      - 'src/[a'
+    comment: sometimes
+    jobs: many
+  - license:
+      spdx-id: Apache-3.0 OR MIT
+dependency:
+  licenses:
+    - name: github.com/foo/[bar
+      license: MIT
+  require_osi_approved: yes please
+`), 0o644))
+
+	problems, err := Validate(file)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.String())
+	}
+	require.Equal(t, []string{
+		base + `:2:3: unknown key "licence"`,
+		file + `:4:7: unknown key "spdx_id", did you mean "spdx-id"?`,
+		file + `:5:16: invalid regular expression in header[].license.pattern: error parsing regexp: missing closing ): ` + "`(unclosed`",
+		file + `:6:5: unknown key "paths_ignore", did you mean "paths-ignore"?`,
+		file + `:9:9: invalid glob "src/[a" in header[].paths[]: syntax error in pattern`,
+		file + `:10:14: unknown header[].comment "sometimes", expected one of ["always" "never" "on-failure"]`,
+		file + `:11:11: header[].jobs must be an integer, got "many"`,
+		file + `:13:16: invalid license expression "Apache-3.0 OR MIT": unknown license ID "Apache-3.0"`,
+		file + `:16:13: invalid pattern "github.com/foo/[bar" in dependency.licenses[].name: syntax error in pattern`,
+		file + `:18:25: dependency.require_osi_approved must be a boolean, got "yes please"`,
+	}, messages)
+}
+
+func TestValidateV1(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".licenserc.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("header:\n  license:\n    spdx-id: Apache-2.0\n  paths-ignore:\n    - dist\n"), 0o644))
+
+	problems, err := Validate(file)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
+
+func TestNewConfigFromFileWithProblems(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".licenserc.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("header:\n  - license:\n      spdx_id: Apache-2.0\n    paths_ignore:\n      - dist\n"), 0o644))
+
+	_, err := NewConfigFromFile(file)
+	require.ErrorContains(t, err, "2 problem(s) found in the config file")
+
+	Lenient = true
+	defer func() { Lenient = false }()
+	config, err := NewConfigFromFile(file)
+	require.NoError(t, err)
+	require.Len(t, config.Headers(), 1)
+}
+
+func TestSchemaIsUpToDate(t *testing.T) {
+	schema, err := Schema()
+	require.NoError(t, err)
+
+	published, err := assets.Asset(SchemaFile)
+	require.NoError(t, err)
+	require.Equal(t, string(schema), string(published),
+		"the published schema is outdated, regenerate it by `license-eye config schema > assets/"+SchemaFile+"`")
+}
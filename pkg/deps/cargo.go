@@ -31,15 +31,79 @@ import (
 )
 
 type CargoMetadata struct {
-	Packages []CargoPackage `json:"packages"`
+	Packages         []CargoPackage `json:"packages"`
+	WorkspaceMembers []string       `json:"workspace_members"`
+	Resolve          *CargoResolve  `json:"resolve"`
+}
+
+// CargoResolve is the resolved dependency graph of the workspace.
+type CargoResolve struct {
+	Nodes []CargoNode `json:"nodes"`
+}
+
+// CargoNode is a package in the dependency graph, with the IDs of the packages it depends on.
+type CargoNode struct {
+	ID           string   `json:"id"`
+	Dependencies []string `json:"dependencies"`
 }
 
 type CargoPackage struct {
+	ID           string `json:"id"`
 	Name         string `json:"name"`
 	Version      string `json:"version"`
 	License      string `json:"license"`
 	LicenseFile  string `json:"license_file"`
 	ManifestPath string `json:"manifest_path"`
+	// Direct is true if the package is a dependency of the workspace members.
+	Direct bool `json:"-"`
+}
+
+// MarkDirect marks the packages that the workspace members depend on as direct.
+func (metadata *CargoMetadata) MarkDirect() {
+	if metadata.Resolve == nil {
+		return
+	}
+	members := make(map[string]bool)
+	for _, member := range metadata.WorkspaceMembers {
+		members[member] = true
+	}
+	direct := make(map[string]bool)
+	for _, node := range metadata.Resolve.Nodes {
+		if !members[node.ID] {
+			continue
+		}
+		for _, dep := range node.Dependencies {
+			direct[dep] = true
+		}
+	}
+	for i := range metadata.Packages {
+		metadata.Packages[i].Direct = direct[metadata.Packages[i].ID]
+	}
+}
+
+// Depend records the dependency graph between the packages into the report, the workspace members are the root.
+func (metadata *CargoMetadata) Depend(report *Report) {
+	if metadata.Resolve == nil {
+		return
+	}
+	names := make(map[string]string)
+	for _, pkg := range metadata.Packages {
+		names[pkg.ID] = pkg.Name
+	}
+	for _, member := range metadata.WorkspaceMembers {
+		names[member] = Root
+	}
+	for _, node := range metadata.Resolve.Nodes {
+		parent, ok := names[node.ID]
+		if !ok {
+			continue
+		}
+		for _, dep := range node.Dependencies {
+			if child, ok := names[dep]; ok && child != Root {
+				report.Depend(parent, child)
+			}
+		}
+	}
 }
 
 type CargoTomlResolver struct {
@@ -80,6 +144,8 @@ func (resolver *CargoTomlResolver) Resolve(cargoTomlFile string, config *ConfigD
 	for i := range metadata.Packages {
 		metadata.Packages[i].License = normalizeLicense(metadata.Packages[i].License)
 	}
+	metadata.MarkDirect()
+	metadata.Depend(report)
 
 	logger.Log.Debugln("Package size:", len(metadata.Packages))
 
@@ -91,14 +157,15 @@ func (resolver *CargoTomlResolver) ResolvePackages(packages []CargoPackage, conf
 	for i := range packages {
 		pkg := packages[i]
 
-		if exclude, _ := config.IsExcluded(pkg.Name, pkg.Version); exclude {
+		if exclude, _ := config.IsExcluded(EcosystemCargo, pkg.Name, pkg.Version); exclude {
 			continue
 		}
-		if l, ok := config.GetUserConfiguredLicense(pkg.Name, pkg.Version); ok {
+		if l, ok := config.GetUserConfiguredLicense(EcosystemCargo, pkg.Name, pkg.Version); ok {
 			report.Resolve(&Result{
 				Dependency:    pkg.Name,
 				LicenseSpdxID: l,
 				Version:       pkg.Version,
+				Direct:        pkg.Direct,
 			})
 			continue
 		}
@@ -109,6 +176,7 @@ func (resolver *CargoTomlResolver) ResolvePackages(packages []CargoPackage, conf
 				Dependency:    pkg.Name,
 				LicenseSpdxID: Unknown,
 				Version:       pkg.Version,
+				Direct:        pkg.Direct,
 			})
 		}
 	}
@@ -158,6 +226,7 @@ func (resolver *CargoTomlResolver) ResolvePackageLicense(config *ConfigDeps, pkg
 		LicenseContent:  string(licenseContent),
 		LicenseSpdxID:   licenseID,
 		Version:         pkg.Version,
+		Direct:          pkg.Direct,
 	})
 
 	return nil
@@ -154,63 +154,52 @@ func CheckWithMatrix(mainLicenseSpdxID string, matrix *CompatibilityMatrix, repo
 	var incompatibleResults []*Result
 	var unknownResults []*Result
 	for _, result := range append(report.Resolved, report.Skipped...) {
-		operator, spdxIDs := parseLicenseExpression(result.LicenseSpdxID)
-		switch operator {
-		case LicenseOperatorAND:
-			if compareAll(spdxIDs, func(spdxID string) bool {
-				return compareCompatible(matrix, spdxID, weakCompatible)
-			}) {
-				continue
-			}
-			if compareAny(spdxIDs, func(spdxID string) bool {
-				return compare(matrix.Incompatible, spdxID)
-			}) {
-				incompatibleResults = append(incompatibleResults, result)
-			}
-
-		case LicenseOperatorOR:
-			if compareAny(spdxIDs, func(spdxID string) bool {
-				return compareCompatible(matrix, spdxID, weakCompatible)
-			}) {
-				continue
-			}
-			if compareAll(spdxIDs, func(spdxID string) bool {
-				return compare(matrix.Incompatible, spdxID)
-			}) {
-				incompatibleResults = append(incompatibleResults, result)
-			}
-
-		default:
-			if compareCompatible(matrix, spdxIDs[0], weakCompatible) {
-				continue
-			}
-			if incompatible := compare(matrix.Incompatible, spdxIDs[0]); incompatible {
-				incompatibleResults = append(incompatibleResults, result)
-				continue
-			}
+		compatible, incompatible := licenseCompatibility(matrix, result.LicenseSpdxID, weakCompatible)
+		if compatible {
+			continue
+		}
+		if incompatible {
+			incompatibleResults = append(incompatibleResults, result)
+			continue
+		}
+		if operator, _ := parseLicenseExpression(result.LicenseSpdxID); operator != LicenseOperatorAND && operator != LicenseOperatorOR {
 			unknownResults = append(unknownResults, result)
 		}
 	}
 
 	if len(incompatibleResults) > 0 || len(unknownResults) > 0 {
-		dWidth, lWidth := float64(len("Dependency")), float64(len("License"))
-		for _, r := range incompatibleResults {
-			dWidth = math.Max(float64(len(r.Dependency)), dWidth)
-			lWidth = math.Max(float64(len(r.LicenseSpdxID)), lWidth)
+		failures := append(append([]*Result{}, incompatibleResults...), unknownResults...)
+
+		// show the direct dependencies that bring the failures, so that developers know which import to change
+		introducedBy := make(map[*Result]string)
+		if report.HasGraph() {
+			for _, r := range failures {
+				introducedBy[r] = strings.Join(report.IntroducedBy(r.Dependency), ", ")
+			}
 		}
-		for _, r := range unknownResults {
+
+		dWidth, lWidth, iWidth := float64(len("Dependency")), float64(len("License")), float64(len("Introduced By"))
+		for _, r := range failures {
 			dWidth = math.Max(float64(len(r.Dependency)), dWidth)
 			lWidth = math.Max(float64(len(r.LicenseSpdxID)), lWidth)
+			iWidth = math.Max(float64(len(introducedBy[r])), iWidth)
 		}
 
-		rowTemplate := fmt.Sprintf("%%-%dv | %%%dv\n", int(dWidth), int(lWidth))
-		s := fmt.Sprintf(rowTemplate, "Dependency", "License")
-		s += fmt.Sprintf(rowTemplate, strings.Repeat("-", int(dWidth)), strings.Repeat("-", int(lWidth)))
-		for _, r := range incompatibleResults {
-			s += fmt.Sprintf(rowTemplate, r.Dependency, r.LicenseSpdxID)
-		}
-		for _, r := range unknownResults {
-			s += fmt.Sprintf(rowTemplate, r.Dependency, r.LicenseSpdxID)
+		var s string
+		if report.HasGraph() {
+			rowTemplate := fmt.Sprintf("%%-%dv | %%%dv | %%v\n", int(dWidth), int(lWidth))
+			s = fmt.Sprintf(rowTemplate, "Dependency", "License", "Introduced By")
+			s += fmt.Sprintf(rowTemplate, strings.Repeat("-", int(dWidth)), strings.Repeat("-", int(lWidth)), strings.Repeat("-", int(iWidth)))
+			for _, r := range failures {
+				s += fmt.Sprintf(rowTemplate, r.Dependency, r.LicenseSpdxID, introducedBy[r])
+			}
+		} else {
+			rowTemplate := fmt.Sprintf("%%-%dv | %%%dv\n", int(dWidth), int(lWidth))
+			s = fmt.Sprintf(rowTemplate, "Dependency", "License")
+			s += fmt.Sprintf(rowTemplate, strings.Repeat("-", int(dWidth)), strings.Repeat("-", int(lWidth)))
+			for _, r := range failures {
+				s += fmt.Sprintf(rowTemplate, r.Dependency, r.LicenseSpdxID)
+			}
 		}
 
 		return fmt.Errorf("the following licenses are unknown or incompatible with the main license, please check manually: %v\n%v", mainLicenseSpdxID, s)
@@ -219,24 +208,88 @@ func CheckWithMatrix(mainLicenseSpdxID string, matrix *CompatibilityMatrix, repo
 	return nil
 }
 
-func parseLicenseExpression(s string) (operator LicenseOperator, spdxIDs []string) {
-	if ss := strings.Split(s, " AND "); len(ss) > 1 {
-		return LicenseOperatorAND, ss
+// licenseCompatibility returns whether the license expression is compatible, and whether it's incompatible, with the
+// main license of the matrix, the parenthesized sub-expressions are checked recursively.
+func licenseCompatibility(matrix *CompatibilityMatrix, expression string, weakCompatible bool) (compatible, incompatible bool) {
+	isCompatible := func(expression string) bool {
+		compatible, _ := licenseCompatibility(matrix, expression, weakCompatible)
+		return compatible
 	}
-	if ss := strings.Split(s, " and "); len(ss) > 1 {
-		return LicenseOperatorAND, ss
+	isIncompatible := func(expression string) bool {
+		_, incompatible := licenseCompatibility(matrix, expression, weakCompatible)
+		return incompatible
 	}
-	if ss := strings.Split(s, " OR "); len(ss) > 1 {
-		return LicenseOperatorOR, ss
+
+	operator, spdxIDs := parseLicenseExpression(expression)
+	switch operator {
+	case LicenseOperatorAND:
+		return compareAll(spdxIDs, isCompatible), compareAny(spdxIDs, isIncompatible)
+	case LicenseOperatorOR:
+		return compareAny(spdxIDs, isCompatible), compareAll(spdxIDs, isIncompatible)
+	default:
+		return compareCompatible(matrix, spdxIDs[0], weakCompatible), compare(matrix.Incompatible, spdxIDs[0])
 	}
-	if ss := strings.Split(s, " or "); len(ss) > 1 {
+}
+
+// parseLicenseExpression splits the license expression by its operator out of the parentheses, OR is split first
+// as AND takes precedence over it, so the parts can be parenthesized expressions. The parentheses that enclose the
+// whole expression are removed.
+func parseLicenseExpression(s string) (operator LicenseOperator, spdxIDs []string) {
+	s = trimParentheses(strings.TrimSpace(s))
+	if ss := splitLicenseExpression(s, "OR"); len(ss) > 1 {
 		return LicenseOperatorOR, ss
 	}
-	if ss := strings.Split(s, " WITH "); len(ss) > 1 {
-		return LicenseOperatorWITH, ss
+	if ss := splitLicenseExpression(s, "AND"); len(ss) > 1 {
+		return LicenseOperatorAND, ss
 	}
-	if ss := strings.Split(s, " with "); len(ss) > 1 {
+	if ss := splitLicenseExpression(s, "WITH"); len(ss) > 1 {
 		return LicenseOperatorWITH, ss
 	}
 	return LicenseOperatorNone, []string{s}
 }
+
+// splitLicenseExpression splits the license expression by the operator (in upper or lower case) out of the parentheses.
+func splitLicenseExpression(s, operator string) []string {
+	separators := []string{" " + operator + " ", " " + strings.ToLower(operator) + " "}
+
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ' ':
+			for _, separator := range separators {
+				if depth == 0 && strings.HasPrefix(s[i:], separator) {
+					parts = append(parts, strings.TrimSpace(s[start:i]))
+					start = i + len(separator)
+					i = start - 1
+					break
+				}
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+// trimParentheses removes the parentheses that enclose the whole license expression.
+func trimParentheses(s string) string {
+	for strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		depth := 0
+		for i := 0; i < len(s)-1; i++ {
+			switch s[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				return s
+			}
+		}
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
@@ -139,6 +139,32 @@ func TestCheckWithMatrix(t *testing.T) {
 		t.Errorf("Should return error")
 	}
 
+	if err := deps.CheckWithMatrix("Apache-2.0", &TestMatrix, &deps.Report{
+		Resolved: []*deps.Result{
+			{
+				Dependency:    "Foo",
+				LicenseSpdxID: "GPL-2.0-only OR (Apache-2.0 AND BSD-3-Clause)",
+			},
+			{
+				Dependency:    "Bar",
+				LicenseSpdxID: "(ISC)",
+			},
+		},
+	}, false); err != nil {
+		t.Errorf("Shouldn't return error, now is `%s`", err.Error())
+	}
+
+	if err := deps.CheckWithMatrix("Apache-2.0", &TestMatrix, &deps.Report{
+		Resolved: []*deps.Result{
+			{
+				Dependency:    "Foo",
+				LicenseSpdxID: "(GPL-2.0-only AND BSD-3-Clause) OR GPL-3.0",
+			},
+		},
+	}, false); err == nil {
+		t.Errorf("Should return error")
+	}
+
 	if err := deps.CheckWithMatrix("Apache-2.0", &TestMatrix, &deps.Report{
 		Resolved: []*deps.Result{
 			{
@@ -172,3 +198,22 @@ func TestCheckWithMatrix(t *testing.T) {
 		t.Errorf("Shouldn't return error")
 	}
 }
+
+func TestCheckWithMatrixIntroducedBy(t *testing.T) {
+	report := &deps.Report{
+		Resolved: []*deps.Result{
+			{Dependency: "Foo", LicenseSpdxID: "Apache-2.0", Direct: true},
+			{Dependency: "Bar", LicenseSpdxID: "LGPL-2.0"},
+		},
+	}
+	report.Depend(deps.Root, "Foo")
+	report.Depend("Foo", "Bar")
+
+	err := deps.CheckWithMatrix("Apache-2.0", &TestMatrix, report, false)
+	if err == nil {
+		t.Fatal("Should return error")
+	}
+	if !strings.Contains(err.Error(), "Bar        | LGPL-2.0 | Foo\n") {
+		t.Errorf("Should return error and contains the dependency Foo that introduces Bar, now is `%s`", err.Error())
+	}
+}
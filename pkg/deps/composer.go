@@ -0,0 +1,221 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// ComposerResolver resolves PHP dependencies from composer.lock, the composer.json or composer.lock file can be
+// listed in the config. Only the packages are resolved, the packages-dev are not. The licenses are read from the
+// lockfile, or identified from the license files of the packages installed in vendor/<name>/.
+type ComposerResolver struct {
+	Resolver
+}
+
+const (
+	composerJSON = "composer.json"
+	composerLock = "composer.lock"
+)
+
+// ComposerPackage is a package in composer.lock, or the project in composer.json.
+type ComposerPackage struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	License    ComposerLicenses  `json:"license"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// ComposerLicenses are the licenses of the Composer package, which are alternatives of each other.
+// It can be either a single license or a list of licenses.
+type ComposerLicenses []string
+
+func (licenses *ComposerLicenses) UnmarshalJSON(data []byte) error {
+	var license string
+	if err := json.Unmarshal(data, &license); err == nil {
+		*licenses = ComposerLicenses{license}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*licenses = list
+	return nil
+}
+
+// ComposerLock is the composer.lock file.
+type ComposerLock struct {
+	Packages    []*ComposerPackage `json:"packages"`
+	PackagesDev []*ComposerPackage `json:"packages-dev"`
+}
+
+// CanResolve determines whether the file is composer.json or composer.lock.
+func (resolver *ComposerResolver) CanResolve(file string) bool {
+	base := filepath.Base(file)
+	return base == composerJSON || base == composerLock
+}
+
+// Resolve resolves licenses of all the packages (not the packages-dev) in composer.lock.
+func (resolver *ComposerResolver) Resolve(file string, config *ConfigDeps, report *Report) error {
+	dir := filepath.Dir(file)
+
+	lock, err := resolver.ParseLock(filepath.Join(dir, composerLock))
+	if err != nil {
+		return err
+	}
+
+	direct := make(map[string]bool)
+	if content, err := os.ReadFile(filepath.Join(dir, composerJSON)); err == nil {
+		var project ComposerPackage
+		if err := json.Unmarshal(content, &project); err != nil {
+			return fmt.Errorf("%v: %w", composerJSON, err)
+		}
+		for _, name := range project.Requirements() {
+			direct[name] = true
+		}
+	}
+
+	installed := make(map[string]bool)
+	for _, pkg := range lock.Packages {
+		installed[strings.ToLower(pkg.Name)] = true
+	}
+
+	for _, pkg := range lock.Packages {
+		name := strings.ToLower(pkg.Name)
+		for _, dep := range pkg.Requirements() {
+			if installed[dep] {
+				report.Depend(name, dep)
+			}
+		}
+
+		if exclude, _ := config.IsExcluded(EcosystemComposer, name, pkg.Version); exclude {
+			continue
+		}
+		if l, ok := config.GetUserConfiguredLicense(EcosystemComposer, name, pkg.Version); ok {
+			report.Resolve(&Result{Dependency: name, LicenseSpdxID: l, Version: pkg.Version, Direct: direct[name]})
+			continue
+		}
+
+		result := resolver.ResolvePackageLicense(filepath.Join(dir, "vendor", filepath.FromSlash(name)), pkg, config)
+		result.Dependency = name
+		result.Direct = direct[name]
+		if result.LicenseSpdxID != "" {
+			report.Resolve(result)
+		} else {
+			result.LicenseSpdxID = Unknown
+			report.Skip(result)
+			logger.Log.Warnln("Failed to resolve the license of dependency:", name, result.ResolveErrors)
+		}
+	}
+	return nil
+}
+
+// ParseLock parses the composer.lock file.
+func (resolver *ComposerResolver) ParseLock(lockFile string) (*ComposerLock, error) {
+	content, err := os.ReadFile(lockFile)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%v is not found, run `composer update` to generate it", lockFile)
+	} else if err != nil {
+		return nil, err
+	}
+	var lock ComposerLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("%v: %w", lockFile, err)
+	}
+	return &lock, nil
+}
+
+// ResolvePackageLicense resolves the license of the package from the license array in the lockfile, which are
+// alternatives of each other, or identifies it from the license file in the vendor directory of the package.
+// The content of the license file is recorded anyway.
+func (resolver *ComposerResolver) ResolvePackageLicense(vendorDir string, pkg *ComposerPackage, config *ConfigDeps) *Result {
+	result := &Result{Dependency: pkg.Name, Version: pkg.Version}
+
+	var licenses []string
+	for _, l := range pkg.License {
+		if l = strings.TrimSpace(l); l == "" {
+			continue
+		}
+		if len(pkg.License) > 1 && strings.Contains(l, " ") {
+			l = "(" + l + ")"
+		}
+		licenses = append(licenses, l)
+	}
+	result.LicenseSpdxID = strings.Join(licenses, " OR ")
+
+	files, err := os.ReadDir(vendorDir)
+	if err != nil {
+		if result.LicenseSpdxID == "" {
+			result.ResolveErrors = append(result.ResolveErrors, fmt.Errorf("no license in composer.lock, and the package is not installed: %w", err))
+		}
+		return result
+	}
+	for _, file := range files {
+		if file.IsDir() || !possibleLicenseFileName.MatchString(file.Name()) {
+			continue
+		}
+		licenseFilePath := filepath.Join(vendorDir, file.Name())
+		content, err := os.ReadFile(licenseFilePath)
+		if err != nil {
+			result.ResolveErrors = append(result.ResolveErrors, err)
+			return result
+		}
+		result.LicenseFilePath = licenseFilePath
+		result.LicenseContent = string(content)
+		if result.LicenseSpdxID != "" {
+			return result
+		}
+		identifier, err := license.Identify(string(content), config.Threshold)
+		if err != nil {
+			result.ResolveErrors = append(result.ResolveErrors, err)
+			return result
+		}
+		result.LicenseSpdxID = identifier
+		return result
+	}
+	if result.LicenseSpdxID == "" {
+		result.ResolveErrors = append(result.ResolveErrors, fmt.Errorf("cannot find the license file"))
+	}
+	return result
+}
+
+// Requirements returns the names of the packages that the package requires, except the platform packages
+// (php, the extensions and the system libraries), sorted by names.
+func (pkg *ComposerPackage) Requirements() []string {
+	var names []string
+	for name := range pkg.Require {
+		name = strings.ToLower(name)
+		if name == "php" || name == "php-64bit" || name == "hhvm" || name == "composer-plugin-api" || name == "composer-runtime-api" ||
+			strings.HasPrefix(name, "ext-") || strings.HasPrefix(name, "lib-") || !strings.Contains(name, "/") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
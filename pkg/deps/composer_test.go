@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+func TestCanResolveComposer(t *testing.T) {
+	resolver := new(deps.ComposerResolver)
+	for _, test := range []struct {
+		fileName string
+		exp      bool
+	}{
+		{"composer.json", true},
+		{"app/composer.lock", true},
+		{"package.json", false},
+	} {
+		if b := resolver.CanResolve(test.fileName); b != test.exp {
+			t.Errorf("ComposerResolver.CanResolve(\"%v\") = %v, want %v", test.fileName, b, test.exp)
+		}
+	}
+}
+
+func TestResolveComposer(t *testing.T) {
+	dir := t.TempDir()
+	writePythonFiles(t, dir, map[string]string{
+		"composer.json": `{
+    "name": "acme/app",
+    "license": "proprietary",
+    "require": {
+        "php": "^8.2",
+        "ext-json": "*",
+        "Laravel/Framework": "^11.0",
+        "acme/unlicensed": "^1.0"
+    },
+    "require-dev": {
+        "phpunit/phpunit": "^11.0"
+    }
+}`,
+		"composer.lock": `{
+    "content-hash": "0",
+    "packages": [
+        {
+            "name": "laravel/framework",
+            "version": "v11.0.0",
+            "require": {"php": "^8.2", "symfony/console": "^7.0", "ext-mbstring": "*"},
+            "license": ["MIT"]
+        },
+        {
+            "name": "symfony/console",
+            "version": "v7.0.0",
+            "license": ["MIT", "GPL-2.0-or-later AND BSD-3-Clause"]
+        },
+        {
+            "name": "acme/unlicensed",
+            "version": "1.0.0",
+            "license": []
+        },
+        {
+            "name": "acme/missing",
+            "version": "2.0.0"
+        },
+        {
+            "name": "acme/single",
+            "version": "3.0.0",
+            "license": "Apache-2.0"
+        }
+    ],
+    "packages-dev": [
+        {
+            "name": "phpunit/phpunit",
+            "version": "11.0.0",
+            "license": ["BSD-3-Clause"]
+        }
+    ]
+}`,
+		"vendor/acme/unlicensed/LICENSE": mitLicense,
+	})
+
+	report := deps.Report{}
+	config := &deps.ConfigDeps{Threshold: 75, Files: []string{filepath.Join(dir, "composer.json")}}
+	if err := deps.Resolve(config, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range report.Resolved {
+		got = append(got, fmt.Sprintf("%v@%v %v %v %v", r.Dependency, r.Version, r.LicenseSpdxID, r.Direct, r.PURL))
+	}
+	want := []string{
+		"laravel/framework@v11.0.0 MIT true pkg:composer/laravel/framework@v11.0.0",
+		"symfony/console@v7.0.0 MIT OR (GPL-2.0-or-later AND BSD-3-Clause) false pkg:composer/symfony/console@v7.0.0",
+		"acme/unlicensed@1.0.0 MIT true pkg:composer/acme/unlicensed@1.0.0",
+		"acme/single@3.0.0 Apache-2.0 false pkg:composer/acme/single@3.0.0",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("resolved\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Dependency != "acme/missing" {
+		t.Errorf("skipped %+v, want acme/missing", report.Skipped)
+	}
+	if paths := report.Paths("symfony/console"); len(paths) != 1 || strings.Join(paths[0], " -> ") != "laravel/framework -> symfony/console" {
+		t.Errorf("Paths(symfony/console) = %v", paths)
+	}
+}
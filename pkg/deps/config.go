@@ -35,6 +35,7 @@ type ConfigDeps struct {
 	Excludes           []Exclude           `yaml:"excludes"`
 	RequireFSFFree     bool                `yaml:"require_fsf_free"`
 	RequireOSIApproved bool                `yaml:"require_osi_approved"`
+	PythonVenv         string              `yaml:"python_venv"`
 }
 
 type ConfigDepLicense struct {
@@ -64,6 +65,10 @@ func (config *ConfigDeps) Finalize(configFile string) error {
 		}
 	}
 
+	if config.PythonVenv != "" && !filepath.IsAbs(config.PythonVenv) {
+		config.PythonVenv = filepath.Join(filepath.Dir(configFileAbsPath), config.PythonVenv)
+	}
+
 	if config.Threshold <= 0 {
 		config.Threshold = DefaultCoverageThreshold
 	}
@@ -71,9 +76,10 @@ func (config *ConfigDeps) Finalize(configFile string) error {
 	return nil
 }
 
-func (config *ConfigDeps) GetUserConfiguredLicense(name, version string) (string, bool) {
+// GetUserConfiguredLicense returns the license configured by the user for the dependency of the ecosystem.
+func (config *ConfigDeps) GetUserConfiguredLicense(ecosystem, name, version string) (string, bool) {
 	for _, license := range config.Licenses {
-		if matched, _ := filepath.Match(license.Name, name); !matched && license.Name != name {
+		if !matchDependency(license.Name, ecosystem, name, version) {
 			continue
 		}
 		if license.Version == "" {
@@ -88,9 +94,11 @@ func (config *ConfigDeps) GetUserConfiguredLicense(name, version string) (string
 	return "", false
 }
 
-func (config *ConfigDeps) IsExcluded(name, version string) (exclude, recursive bool) {
+// IsExcluded returns whether the dependency of the ecosystem is excluded by the user, and whether its transitive
+// dependencies are excluded too.
+func (config *ConfigDeps) IsExcluded(ecosystem, name, version string) (exclude, recursive bool) {
 	for _, license := range config.Excludes {
-		if matched, _ := filepath.Match(license.Name, name); !matched && license.Name != name {
+		if !matchDependency(license.Name, ecosystem, name, version) {
 			continue
 		}
 		if license.Version == "" {
@@ -104,3 +112,29 @@ func (config *ConfigDeps) IsExcluded(name, version string) (exclude, recursive b
 	}
 	return false, false
 }
+
+// matchDependency returns whether the dependency matches the pattern, which is the name of the dependency, a glob
+// pattern of the names, or a (glob pattern of) package URL, such as pkg:npm/%40babel/* and pkg:golang/golang.org/x/*,
+// the package URL pattern matches the version too if it has one, such as pkg:cargo/serde@1.0.0.
+func matchDependency(pattern, ecosystem, name, version string) bool {
+	if !strings.HasPrefix(pattern, purlScheme) {
+		matched, _ := filepath.Match(pattern, name)
+		return matched || pattern == name
+	}
+
+	dep := &Result{Ecosystem: ecosystem, Dependency: name}
+	if segments := strings.Split(pattern, "/"); strings.LastIndex(segments[len(segments)-1], "@") > 0 {
+		dep.Version = version
+	}
+	purl := PackageURL(dep)
+	if purl == "" {
+		return false
+	}
+	// the pattern may be written without percent-encoding, such as pkg:npm/@babel/core
+	for _, p := range []string{purl, unescapePURL(purl)} {
+		if matched, _ := filepath.Match(pattern, p); matched || pattern == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+func TestUserConfiguredLicenseWithPackageURL(t *testing.T) {
+	config := &deps.ConfigDeps{Licenses: []*deps.ConfigDepLicense{
+		{Name: "pkg:npm/%40babel/*", License: "MIT"},
+		{Name: "pkg:cargo/serde@1.0.0", License: "Apache-2.0"},
+		{Name: "pkg:golang/golang.org/x/*", Version: "v0.1.0", License: "BSD-3-Clause"},
+		{Name: "lodash", License: "MIT"},
+	}}
+
+	tests := []struct {
+		ecosystem, name, version string
+		license                  string
+	}{
+		{deps.EcosystemNpm, "@babel/core", "7.0.0", "MIT"},
+		{deps.EcosystemGolang, "@babel/core", "7.0.0", ""},
+		{deps.EcosystemCargo, "serde", "1.0.0", "Apache-2.0"},
+		{deps.EcosystemCargo, "serde", "1.0.1", ""},
+		{deps.EcosystemGolang, "golang.org/x/mod", "v0.1.0", "BSD-3-Clause"},
+		{deps.EcosystemGolang, "golang.org/x/mod", "v0.2.0", ""},
+		{deps.EcosystemNpm, "lodash", "4.17.21", "MIT"},
+		{deps.EcosystemCargo, "lodash", "1.0.0", "MIT"},
+	}
+	for _, test := range tests {
+		license, ok := config.GetUserConfiguredLicense(test.ecosystem, test.name, test.version)
+		if license != test.license || ok != (test.license != "") {
+			t.Errorf("license of %v %v@%v = %v, want %v", test.ecosystem, test.name, test.version, license, test.license)
+		}
+	}
+}
+
+func TestIsExcludedWithPackageURL(t *testing.T) {
+	config := &deps.ConfigDeps{Excludes: []deps.Exclude{
+		{Name: "pkg:npm/@types/*", Recursive: true},
+		{Name: "pkg:maven/org.apache.skywalking/*", Version: "9.0.0"},
+	}}
+
+	if exclude, recursive := config.IsExcluded(deps.EcosystemNpm, "@types/node", "20.0.0"); !exclude || !recursive {
+		t.Errorf("@types/node should be excluded recursively, got %v %v", exclude, recursive)
+	}
+	if exclude, _ := config.IsExcluded(deps.EcosystemGem, "@types/node", "20.0.0"); exclude {
+		t.Error("@types/node of gem shouldn't be excluded")
+	}
+	if exclude, _ := config.IsExcluded(deps.EcosystemMaven, "org.apache.skywalking:apm-agent", "9.0.0"); !exclude {
+		t.Error("org.apache.skywalking:apm-agent@9.0.0 should be excluded")
+	}
+	if exclude, _ := config.IsExcluded(deps.EcosystemMaven, "org.apache.skywalking:apm-agent", "8.0.0"); exclude {
+		t.Error("org.apache.skywalking:apm-agent@8.0.0 shouldn't be excluded")
+	}
+}
+
+func TestDirectModules(t *testing.T) {
+	goMod := filepath.Join(t.TempDir(), "go.mod")
+	content := `module example.com/foo
+
+go 1.23
+
+require (
+	github.com/foo/direct v1.0.0
+	github.com/foo/indirect v1.0.0 // indirect
+)
+
+require github.com/foo/single v1.0.0
+`
+	if err := os.WriteFile(goMod, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	direct, err := new(deps.GoModResolver).DirectModules(goMod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(direct) != 2 || !direct["github.com/foo/direct"] || !direct["github.com/foo/single"] {
+		t.Errorf("unexpected direct modules: %v", direct)
+	}
+}
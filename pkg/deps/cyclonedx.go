@@ -0,0 +1,299 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	cdxSpecVersion = "1.5"
+	cdxNamespace   = "http://cyclonedx.org/schema/bom/1.5"
+	cdxSchema      = "http://cyclonedx.org/schema/bom-1.5.schema.json"
+
+	// cdxSkippedProperty flags the components whose licenses cannot be resolved.
+	cdxSkippedProperty = "license-eye:skipped"
+	// cdxErrorsProperty carries the errors occurred while resolving the licenses of the skipped components.
+	cdxErrorsProperty = "license-eye:resolve-errors"
+)
+
+// cdxToolPattern splits the tool into its name and version, such as license-eye-0.6.0.
+var cdxToolPattern = regexp.MustCompile(`^(.+?)-(v?\d.*)$`)
+
+type cdxBOM struct {
+	XMLName      xml.Name         `json:"-" xml:"http://cyclonedx.org/schema/bom/1.5 bom"`
+	Schema       string           `json:"$schema" xml:"-"`
+	BOMFormat    string           `json:"bomFormat" xml:"-"`
+	SpecVersion  string           `json:"specVersion" xml:"-"`
+	SerialNumber string           `json:"serialNumber" xml:"serialNumber,attr"`
+	Version      int              `json:"version" xml:"version,attr"`
+	Metadata     *cdxMetadata     `json:"metadata" xml:"metadata"`
+	Components   []*cdxComponent  `json:"components" xml:"components>component"`
+	Dependencies []*cdxDependency `json:"dependencies" xml:"dependencies>dependency"`
+}
+
+type cdxMetadata struct {
+	Timestamp string        `json:"timestamp" xml:"timestamp"`
+	Tools     *cdxTools     `json:"tools" xml:"tools"`
+	Component *cdxComponent `json:"component" xml:"component"`
+}
+
+type cdxTools struct {
+	Components []*cdxComponent `json:"components" xml:"components>component"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type" xml:"type,attr"`
+	BOMRef     string        `json:"bom-ref,omitempty" xml:"bom-ref,attr,omitempty"`
+	Supplier   *cdxSupplier  `json:"supplier,omitempty" xml:"supplier,omitempty"`
+	Name       string        `json:"name" xml:"name"`
+	Version    string        `json:"version,omitempty" xml:"version,omitempty"`
+	Licenses   cdxLicenses   `json:"licenses,omitempty" xml:"licenses,omitempty"`
+	PURL       string        `json:"purl,omitempty" xml:"purl,omitempty"`
+	Properties cdxProperties `json:"properties,omitempty" xml:"properties,omitempty"`
+	Evidence   *cdxEvidence  `json:"evidence,omitempty" xml:"evidence,omitempty"`
+}
+
+type cdxSupplier struct {
+	Name string `json:"name" xml:"name"`
+}
+
+// cdxLicenses is a list of licenses, or a single license expression.
+type cdxLicenses []*cdxLicenseChoice
+
+type cdxLicenseChoice struct {
+	License    *cdxLicense `json:"license,omitempty"`
+	Expression string      `json:"expression,omitempty"`
+}
+
+type cdxLicense struct {
+	ID   string   `json:"id,omitempty" xml:"id,omitempty"`
+	Name string   `json:"name,omitempty" xml:"name,omitempty"`
+	Text *cdxText `json:"text,omitempty" xml:"text,omitempty"`
+}
+
+type cdxText struct {
+	ContentType string `json:"contentType" xml:"content-type,attr"`
+	Content     string `json:"content" xml:",chardata"`
+}
+
+// cdxProperties are the name-value properties of a component.
+type cdxProperties []*cdxProperty
+
+type cdxProperty struct {
+	Name  string `json:"name" xml:"name,attr"`
+	Value string `json:"value" xml:",chardata"`
+}
+
+type cdxEvidence struct {
+	Licenses cdxLicenses `json:"licenses" xml:"licenses"`
+}
+
+type cdxDependency struct {
+	Ref       string  `json:"ref" xml:"ref,attr"`
+	DependsOn cdxRefs `json:"dependsOn,omitempty" xml:"dependency,omitempty"`
+}
+
+// cdxRefs are the references to the components that a component depends on.
+type cdxRefs []string
+
+// MarshalXML writes the license choices as the license and expression elements of the licenses element.
+func (licenses cdxLicenses) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, choice := range licenses {
+		var err error
+		if choice.License != nil {
+			err = e.EncodeElement(choice.License, xml.StartElement{Name: xml.Name{Local: "license"}})
+		} else {
+			err = e.EncodeElement(choice.Expression, xml.StartElement{Name: xml.Name{Local: "expression"}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// MarshalXML writes the properties as the property elements of the properties element.
+func (properties cdxProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, property := range properties {
+		if err := e.EncodeElement(property, xml.StartElement{Name: xml.Name{Local: "property"}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// MarshalXML writes every reference as an empty dependency element.
+func (refs cdxRefs) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, ref := range refs {
+		dependency := xml.StartElement{Name: start.Name, Attr: []xml.Attr{{Name: xml.Name{Local: "ref"}, Value: ref}}}
+		if err := e.EncodeElement("", dependency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newCycloneDXBOM returns the CycloneDX BOM of the report, the project is the metadata component, and the components
+// depend on each other as in the dependency graph, see sbomDependencies. The license contents are attached as the
+// license evidences of the components, and the components whose licenses cannot be resolved are flagged by the
+// license-eye:skipped property.
+func newCycloneDXBOM(report *Report, sbom *SBOM) *cdxBOM {
+	refs := make(map[string]bool)
+	uniqueRef := func(ref string) string {
+		unique := ref
+		for i := 2; refs[unique]; i++ {
+			unique = fmt.Sprintf("%v-%d", ref, i)
+		}
+		refs[unique] = true
+		return unique
+	}
+
+	tool, toolVersion := sbom.Tool, ""
+	if m := cdxToolPattern.FindStringSubmatch(sbom.Tool); m != nil {
+		tool, toolVersion = m[1], m[2]
+	}
+
+	root := &cdxComponent{
+		Type:    "application",
+		BOMRef:  uniqueRef(sbom.Name),
+		Name:    sbom.Name,
+		Version: sbom.Version,
+	}
+	if sbom.Supplier != "" {
+		root.Supplier = &cdxSupplier{Name: sbom.Supplier}
+	}
+	if expression, ok := licenseExpression(sbom.License); ok {
+		root.Licenses = cdxLicenses{cdxLicenseOf(expression)}
+	}
+
+	bom := &cdxBOM{
+		Schema:       cdxSchema,
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cdxSpecVersion,
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Metadata: &cdxMetadata{
+			Timestamp: sbom.Created.UTC().Format(time.RFC3339),
+			Tools:     &cdxTools{Components: []*cdxComponent{{Type: "application", Name: tool, Version: toolVersion}}},
+			Component: root,
+		},
+		Components: []*cdxComponent{},
+	}
+	results := sbomResults(report)
+	bomRefs := make(map[*Result]string)
+	for _, result := range results {
+		component := &cdxComponent{
+			Type:    "library",
+			Name:    result.Dependency,
+			Version: result.Version,
+			PURL:    PackageURL(result),
+		}
+		if component.PURL != "" {
+			component.BOMRef = uniqueRef(component.PURL)
+		} else {
+			component.BOMRef = uniqueRef(result.Dependency + "@" + result.Version)
+		}
+
+		if isSkipped(report, result) {
+			component.Properties = cdxProperties{{Name: cdxSkippedProperty, Value: "true"}}
+			if len(result.ResolveErrors) > 0 {
+				component.Properties = append(component.Properties, &cdxProperty{
+					Name:  cdxErrorsProperty,
+					Value: strings.TrimPrefix(resolveErrors(result), ": "),
+				})
+			}
+		} else if result.LicenseSpdxID != "" && result.LicenseSpdxID != Unknown {
+			license := &cdxLicense{Name: result.LicenseSpdxID}
+			if expression, ok := licenseExpression(result.LicenseSpdxID); ok {
+				choice := cdxLicenseOf(expression)
+				component.Licenses = cdxLicenses{choice}
+				if choice.License != nil {
+					license = &cdxLicense{ID: expression}
+				} else {
+					license = &cdxLicense{Name: expression}
+				}
+			} else {
+				component.Licenses = cdxLicenses{{License: &cdxLicense{Name: result.LicenseSpdxID}}}
+			}
+			if strings.TrimSpace(result.LicenseContent) != "" {
+				license.Text = &cdxText{ContentType: "text/plain", Content: result.LicenseContent}
+				component.Evidence = &cdxEvidence{Licenses: cdxLicenses{{License: license}}}
+			}
+		}
+
+		bom.Components = append(bom.Components, component)
+		bomRefs[result] = component.BOMRef
+	}
+
+	dependencies := sbomDependencies(report, results)
+	dependsOn := func(name string) cdxRefs {
+		var refs cdxRefs
+		for _, dependency := range dependencies(name) {
+			refs = append(refs, bomRefs[dependency])
+		}
+		return refs
+	}
+	bom.Dependencies = []*cdxDependency{{Ref: root.BOMRef, DependsOn: dependsOn(Root)}}
+	if report.HasGraph() {
+		for _, result := range results {
+			bom.Dependencies = append(bom.Dependencies, &cdxDependency{Ref: bomRefs[result], DependsOn: dependsOn(result.Dependency)})
+		}
+	}
+
+	return bom
+}
+
+// cdxLicenseOf returns the license of the single license ID, or the license expression otherwise.
+func cdxLicenseOf(expression string) *cdxLicenseChoice {
+	if strings.ContainsAny(expression, " ()+") || strings.Contains(expression, "LicenseRef-") {
+		return &cdxLicenseChoice{Expression: expression}
+	}
+	return &cdxLicenseChoice{License: &cdxLicense{ID: expression}}
+}
+
+func writeCycloneDXJSON(w io.Writer, report *Report, sbom *SBOM) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(newCycloneDXBOM(report, sbom))
+}
+
+func writeCycloneDXXML(w io.Writer, report *Report, sbom *SBOM) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(newCycloneDXBOM(report, sbom)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -27,10 +27,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/apache/skywalking-eyes/internal/logger"
 	"github.com/apache/skywalking-eyes/pkg/license"
 
+	"golang.org/x/mod/modfile"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -46,6 +48,11 @@ func (resolver *GoModResolver) CanResolve(file string) bool {
 
 // Resolve resolves licenses of all dependencies declared in the go.mod file.
 func (resolver *GoModResolver) Resolve(goModFile string, config *ConfigDeps, report *Report) error {
+	direct, err := resolver.DirectModules(goModFile)
+	if err != nil {
+		return err
+	}
+
 	if err := os.Chdir(filepath.Dir(goModFile)); err != nil {
 		return err
 	}
@@ -73,11 +80,18 @@ func (resolver *GoModResolver) Resolve(goModFile string, config *ConfigDeps, rep
 			}
 			return err
 		}
+		m.Indirect = !direct[m.Path]
 		modules = append(modules, &m)
 	}
 
 	logger.Log.Debugln("Module size:", len(modules))
 
+	if graph, err := exec.Command("go", "mod", "graph").Output(); err != nil {
+		logger.Log.Warnf("Failed to load the module graph: %v", err)
+	} else {
+		resolver.ResolveGraph(graph, direct, report)
+	}
+
 	return resolver.ResolvePackages(modules, config, report)
 }
 
@@ -85,14 +99,15 @@ func (resolver *GoModResolver) Resolve(goModFile string, config *ConfigDeps, rep
 func (resolver *GoModResolver) ResolvePackages(modules []*packages.Module, config *ConfigDeps, report *Report) error {
 	for _, module := range modules {
 		func() {
-			if excluded, _ := config.IsExcluded(module.Path, module.Version); excluded {
+			if excluded, _ := config.IsExcluded(EcosystemGolang, module.Path, module.Version); excluded {
 				return
 			}
-			if l, ok := config.GetUserConfiguredLicense(module.Path, module.Version); ok {
+			if l, ok := config.GetUserConfiguredLicense(EcosystemGolang, module.Path, module.Version); ok {
 				report.Resolve(&Result{
 					Dependency:    module.Path,
 					LicenseSpdxID: l,
 					Version:       module.Version,
+					Direct:        !module.Indirect,
 				})
 				return
 			}
@@ -103,6 +118,7 @@ func (resolver *GoModResolver) ResolvePackages(modules []*packages.Module, confi
 					Dependency:    module.Path,
 					LicenseSpdxID: Unknown,
 					Version:       module.Version,
+					Direct:        !module.Indirect,
 				})
 			}
 		}()
@@ -110,6 +126,46 @@ func (resolver *GoModResolver) ResolvePackages(modules []*packages.Module, confi
 	return nil
 }
 
+// DirectModules returns the modules required directly by the go.mod file, those not marked as indirect.
+func (resolver *GoModResolver) DirectModules(goModFile string) (map[string]bool, error) {
+	content, err := os.ReadFile(goModFile)
+	if err != nil {
+		return nil, err
+	}
+	file, err := modfile.ParseLax(goModFile, content, nil)
+	if err != nil {
+		return nil, err
+	}
+	direct := make(map[string]bool)
+	for _, require := range file.Require {
+		if !require.Indirect {
+			direct[require.Mod.Path] = true
+		}
+	}
+	return direct, nil
+}
+
+// ResolveGraph records the module requirement graph, in the format of `go mod graph`, into the report,
+// the main module only brings the direct modules, the indirect ones it requires are brought by others.
+func (resolver *GoModResolver) ResolveGraph(graph []byte, direct map[string]bool, report *Report) {
+	for _, line := range strings.Split(string(graph), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		parent, version, _ := strings.Cut(fields[0], "@")
+		if version == "" {
+			// the main module has no version
+			parent = Root
+		}
+		child, _, _ := strings.Cut(fields[1], "@")
+		if child == "go" || child == "toolchain" || parent == Root && !direct[child] {
+			continue
+		}
+		report.Depend(parent, child)
+	}
+}
+
 var possibleLicenseFileName = regexp.MustCompile(`(?i)^LICENSE|LICENCE(\.txt)?|COPYING(\.txt)?$`)
 
 func (resolver *GoModResolver) ResolvePackageLicense(config *ConfigDeps, module *packages.Module, report *Report) error {
@@ -141,6 +197,7 @@ func (resolver *GoModResolver) ResolvePackageLicense(config *ConfigDeps, module
 				LicenseContent:  string(content),
 				LicenseSpdxID:   identifier,
 				Version:         module.Version,
+				Direct:          !module.Indirect,
 			})
 			return nil
 		}
@@ -0,0 +1,366 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+)
+
+// GradleResolver resolves the dependencies on the runtime classpath of the Gradle project in the directory of the build
+// file, the subprojects are resolved by listing their own build files.
+// The resolved classpath is read from gradle.lockfile (dependency locking) if it exists next to the build file,
+// so no Gradle run is needed, otherwise it's read from the output of the "dependencies" task, which is run by
+// gradlew or gradle. The licenses are read from the jars and the POMs in the Gradle cache
+// ($GRADLE_USER_HOME/caches/modules-2, GRADLE_USER_HOME defaults to ~/.gradle).
+type GradleResolver struct {
+	MavenPomResolver
+	gradle string
+}
+
+const gradleLockfile = "gradle.lockfile"
+
+var (
+	gradleBuildFiles   = map[string]bool{"build.gradle": true, "build.gradle.kts": true}
+	reGradleTreeEntry  = regexp.MustCompile(`^([| ]*)[+\\]--- (.+)$`)
+	reGradleCoordinate = regexp.MustCompile(`["']([\w.\-]+):([\w.\-]+)(?::[^"'\s]*)?["']`)
+)
+
+// CanResolve determines whether the file is a Gradle build script, the settings file and the lockfile are not resolved
+// on their own, as they belong to the project of the build script next to them.
+func (resolver *GradleResolver) CanResolve(file string) bool {
+	return gradleBuildFiles[filepath.Base(file)]
+}
+
+// Resolve resolves licenses of all dependencies on the runtime classpath of the Gradle project.
+func (resolver *GradleResolver) Resolve(buildFile string, config *ConfigDeps, report *Report) error {
+	dir := filepath.Dir(buildFile)
+
+	var deps []*Dependency
+	if lockfile := filepath.Join(dir, gradleLockfile); fileExists(lockfile) {
+		buf, err := os.ReadFile(lockfile)
+		if err != nil {
+			return err
+		}
+		deps = FlattenDependencies(LoadGradleLockfile(buf, DeclaredGradleDependencies(dir)), config)
+	} else {
+		if err := resolver.FindGradle(dir); err != nil {
+			return err
+		}
+		buf, err := resolver.LoadDependenciesReport(dir)
+		if err != nil {
+			return err
+		}
+		deps = FlattenDependencies(LoadGradleDependenciesTree(buf), config)
+	}
+
+	resolver.repo = filepath.Join(GradleUserHome(), "caches", "modules-2", "files-2.1")
+
+	for _, dep := range deps {
+		report.Depend(dep.Parent, dep.Name())
+		for _, dependent := range dep.Dependents {
+			report.Depend(dependent, dep.Name())
+		}
+		if l, ok := config.GetUserConfiguredLicense(EcosystemMaven, dep.Name(), dep.Version); ok {
+			report.Resolve(&Result{
+				Dependency:    dep.Name(),
+				LicenseSpdxID: l,
+				Version:       dep.Version,
+				Direct:        dep.Direct,
+			})
+			continue
+		}
+		state := NotFound
+		if err := resolver.ResolveLicense(config, &state, dep, report); err != nil {
+			logger.Log.Warnf("Failed to resolve the license of <%s>: %v. %v\n", dep.Name(), state.String(), err)
+			report.Skip(&Result{
+				Dependency:    dep.Name(),
+				LicenseSpdxID: Unknown,
+				Version:       dep.Version,
+				Direct:        dep.Direct,
+				ResolveErrors: []error{err},
+			})
+		}
+	}
+	return nil
+}
+
+// FindGradle finds the Gradle wrapper in the project directory, or the parent directories up to the root project
+// (the one with the settings file), so the wrapper of the root project is used for the subprojects, or the gradle in
+// the PATH.
+func (resolver *GradleResolver) FindGradle(dir string) error {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	for ; ; dir = filepath.Dir(dir) {
+		if wrapper := filepath.Join(dir, "gradlew"); fileExists(wrapper) {
+			logger.Log.Debugln("gradlew is found, will use gradlew by default")
+			resolver.gradle = wrapper
+			return nil
+		}
+		root := fileExists(filepath.Join(dir, "settings.gradle")) || fileExists(filepath.Join(dir, "settings.gradle.kts"))
+		if root || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	gradle, err := exec.LookPath("gradle")
+	if err != nil {
+		return fmt.Errorf("neither found gradlew nor gradle, lock the dependencies (gradle.lockfile) to resolve them without Gradle")
+	}
+	resolver.gradle = gradle
+	return nil
+}
+
+// LoadDependenciesReport runs the "dependencies" task of the Gradle project in the directory and returns the report,
+// the subprojects are not included, their build files should be listed to resolve their dependencies.
+func (resolver *GradleResolver) LoadDependenciesReport(dir string) ([]byte, error) {
+	var stdout bytes.Buffer
+	cmd := exec.Command(resolver.gradle, "dependencies", "--console=plain") // #nosec G204
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	logger.Log.Debugf("loading dependencies with command %v", cmd.Args)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run the dependencies task: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// ResolveLicense searches the license in the jar and then the POM of the dependency in the Gradle cache.
+func (resolver *GradleResolver) ResolveLicense(config *ConfigDeps, state *State, dep *Dependency, report *Report) error {
+	jarFile, pomFile := resolver.CachedArtifacts(dep)
+	if jarFile == "" && pomFile == "" {
+		return fmt.Errorf("not found in the Gradle cache %v", resolver.repo)
+	}
+
+	var errs []error
+	if jarFile != "" {
+		result, err := resolver.ResolveJar(config, state, jarFile, dep.Version)
+		if result != nil {
+			result.Dependency = dep.Name()
+			result.Direct = dep.Direct
+			report.Resolve(result)
+			return nil
+		}
+		errs = append(errs, err)
+	}
+
+	if pomFile != "" {
+		result, err := resolver.ResolveLicenseFromPom(config, state, dep, pomFile)
+		if result != nil {
+			result.Direct = dep.Direct
+			report.Resolve(result)
+			return nil
+		}
+		errs = append(errs, err)
+	}
+
+	return fmt.Errorf("failed to resolve license for <%s> from jar or pom: %+v", dep.Name(), errs)
+}
+
+// CachedArtifacts returns the jar and the POM of the dependency in the Gradle cache, which are laid out as
+// <group>/<artifact>/<version>/<sha1>/<artifact>-<version>.<ext>, or empty strings if they're not cached.
+func (resolver *GradleResolver) CachedArtifacts(dep *Dependency) (jarFile, pomFile string) {
+	dir := filepath.Join(resolver.repo, dep.GroupID, dep.ArtifactID, dep.Version)
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*", dep.Jar())); len(matches) > 0 {
+		jarFile = matches[0]
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*", dep.Pom())); len(matches) > 0 {
+		pomFile = matches[0]
+	}
+	return jarFile, pomFile
+}
+
+// GradleUserHome returns the Gradle user home, where the Gradle cache is.
+func GradleUserHome() string {
+	if home := os.Getenv("GRADLE_USER_HOME"); home != "" {
+		return home
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".gradle"
+	}
+	return filepath.Join(home, ".gradle")
+}
+
+// DeclaredGradleDependencies returns the "group:artifact" of the dependencies declared in the build files and the
+// version catalog of the Gradle project in the directory.
+func DeclaredGradleDependencies(dir string) map[string]bool {
+	declared := make(map[string]bool)
+	for _, file := range []string{"build.gradle", "build.gradle.kts", filepath.Join("gradle", "libs.versions.toml")} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		for _, match := range reGradleCoordinate.FindAllStringSubmatch(string(content), -1) {
+			declared[match[1]+":"+match[2]] = true
+		}
+	}
+	return declared
+}
+
+// LoadGradleLockfile loads the dependencies on the runtime classpaths from the Gradle lockfile, the lockfile has no
+// dependency tree, the dependencies in the declared ones are direct.
+func LoadGradleLockfile(data []byte, declared map[string]bool) []*Dependency {
+	var deps []*Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		coordinate, configurations, ok := strings.Cut(line, "=")
+		if !ok || coordinate == "empty" {
+			continue
+		}
+		runtime := false
+		for _, configuration := range strings.Split(configurations, ",") {
+			runtime = runtime || isRuntimeClasspath(configuration)
+		}
+		parts := strings.Split(coordinate, ":")
+		if !runtime || len(parts) != 3 {
+			continue
+		}
+		dep := &Dependency{GroupID: parts[0], ArtifactID: parts[1], Version: parts[2]}
+		dep.Direct = declared[dep.Name()]
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// LoadGradleDependenciesTree loads the dependency trees of the runtime classpaths from the report of the Gradle
+// "dependencies" task. The dependencies of the project dependencies are lifted to the dependent, the dependency
+// constraints and the unresolved dependencies are left out, and the repeated dependencies are listed once, with the
+// other dependencies that bring them as their Dependents.
+func LoadGradleDependenciesTree(data []byte) []*Dependency {
+	var deps []*Dependency
+	unique := make(map[string]*Dependency)
+
+	// stack[i] is the dependency at level i, nil if it's not a module dependency
+	var stack []*Dependency
+	runtime := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		entry := reGradleTreeEntry.FindStringSubmatch(line)
+		if entry == nil {
+			if name, _, _ := strings.Cut(line, " "); name != "" && !strings.ContainsAny(name, "|\\+") {
+				runtime = isRuntimeClasspath(name)
+				stack = stack[:0]
+			}
+			continue
+		}
+		if !runtime {
+			continue
+		}
+
+		level := len(entry[1]) / 5
+		if level > len(stack) {
+			continue
+		}
+		stack = stack[:level]
+
+		dep := parseGradleTreeEntry(entry[2])
+		if dep == nil {
+			stack = append(stack, nil)
+			continue
+		}
+		var parent *Dependency
+		for i := level - 1; i >= 0 && parent == nil; i-- {
+			parent = stack[i]
+		}
+
+		if existing, ok := unique[dep.Path()]; ok {
+			// the repeated dependency is listed once, but it's still brought by this parent
+			dependent := Root
+			if parent != nil {
+				dependent = parent.Name()
+			} else {
+				existing.Direct = true
+			}
+			if dependent != existing.Parent && dependent != existing.Name() && !slices.Contains(existing.Dependents, dependent) {
+				existing.Dependents = append(existing.Dependents, dependent)
+			}
+			stack = append(stack, existing)
+			continue
+		}
+		unique[dep.Path()] = dep
+		stack = append(stack, dep)
+
+		if parent == nil {
+			dep.Direct = true
+			deps = append(deps, dep)
+		} else {
+			dep.Parent = parent.Name()
+			parent.TransitiveDeps = append(parent.TransitiveDeps, dep)
+		}
+	}
+	return deps
+}
+
+// parseGradleTreeEntry parses the entry in the Gradle dependency tree, such as "group:artifact:1.0 -> 1.1 (*)",
+// it returns nil if the entry is not a resolved module dependency.
+func parseGradleTreeEntry(entry string) *Dependency {
+	if strings.HasSuffix(entry, " (c)") || strings.HasSuffix(entry, " (n)") || strings.HasSuffix(entry, " FAILED") {
+		return nil
+	}
+	entry = strings.TrimSuffix(entry, " (*)")
+
+	coordinate, selected, conflict := strings.Cut(entry, " -> ")
+	parts := strings.Split(coordinate, ":")
+	if strings.HasPrefix(entry, "project ") || strings.HasPrefix(selected, "project ") || len(parts) < 2 || len(parts) > 3 {
+		return nil
+	}
+	dep := &Dependency{GroupID: parts[0], ArtifactID: parts[1]}
+	switch {
+	case conflict:
+		dep.Version = selected
+	case len(parts) == 3:
+		dep.Version = parts[2]
+	default:
+		return nil
+	}
+	return dep
+}
+
+// isRuntimeClasspath determines whether the Gradle configuration is a runtime classpath of the production code,
+// such as runtimeClasspath, or releaseRuntimeClasspath of the Android projects.
+func isRuntimeClasspath(configuration string) bool {
+	configuration = strings.TrimSpace(configuration)
+	if configuration != "runtimeClasspath" && !strings.HasSuffix(configuration, "RuntimeClasspath") {
+		return false
+	}
+	return !strings.Contains(configuration, "test") && !strings.Contains(configuration, "Test")
+}
+
+func fileExists(file string) bool {
+	info, err := os.Stat(file)
+	return err == nil && !info.IsDir()
+}
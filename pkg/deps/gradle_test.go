@@ -0,0 +1,199 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+func TestCanResolveGradle(t *testing.T) {
+	resolver := new(deps.GradleResolver)
+	for _, test := range []struct {
+		fileName string
+		exp      bool
+	}{
+		{"build.gradle", true},
+		{"app/build.gradle.kts", true},
+		{"settings.gradle", false},
+		{"settings.gradle.kts", false},
+		{"gradle.lockfile", false},
+		{"gradle.properties", false},
+		{"pom.xml", false},
+	} {
+		if b := resolver.CanResolve(test.fileName); b != test.exp {
+			t.Errorf("GradleResolver.CanResolve(\"%v\") = %v, want %v", test.fileName, b, test.exp)
+		}
+	}
+}
+
+func TestFindGradle(t *testing.T) {
+	t.Setenv("PATH", "")
+	dir := t.TempDir()
+	writePythonFiles(t, dir, map[string]string{
+		"gradlew":                "",
+		"settings.gradle":        "include 'app'\n",
+		"app/build.gradle":       "",
+		"other/settings.gradle":  "",
+		"other/lib/build.gradle": "",
+	})
+
+	resolver := new(deps.GradleResolver)
+	if err := resolver.FindGradle(filepath.Join(dir, "app")); err != nil {
+		t.Errorf("the wrapper of the root project should be found for the subproject: %v", err)
+	}
+	if err := resolver.FindGradle(filepath.Join(dir, "other", "lib")); err == nil {
+		t.Errorf("the wrapper outside of the root project should not be found")
+	}
+}
+
+func TestLoadGradleDependenciesTree(t *testing.T) {
+	report := `
+> Task :dependencies
+
+------------------------------------------------------------
+Root project 'demo'
+------------------------------------------------------------
+
+compileClasspath - Compile classpath for source set 'main'.
+\--- org.projectlombok:lombok:1.18.30
+
+runtimeClasspath - Runtime classpath of source set 'main'.
++--- com.google.guava:guava:32.1.3-jre
+|    +--- com.google.guava:failureaccess:1.0.1
+|    \--- org.checkerframework:checker-qual:3.37.0 -> 3.41.0
++--- project :core
+|    +--- org.slf4j:slf4j-api:2.0.9
+|    \--- com.google.guava:guava:32.1.3-jre (*)
++--- org.checkerframework:checker-qual:3.41.0
++--- com.example:utils:1.0
+|    \--- com.google.guava:guava:32.1.3-jre (*)
++--- org.apache.commons:commons-lang3 -> 3.14.0
++--- org.slf4j:slf4j-api:{strictly 2.0.9} -> 2.0.9 (c)
+\--- com.example:missing:1.0 FAILED
+
+testRuntimeClasspath - Runtime classpath of source set 'test'.
+\--- junit:junit:4.13.2
+     \--- org.hamcrest:hamcrest-core:1.3
+
+(*) - Indicates repeated occurrences of a transitive dependency subtree.
+`
+	trees := deps.LoadGradleDependenciesTree([]byte(report))
+	got := make(map[string]*deps.Dependency)
+	for _, dep := range deps.FlattenDependencies(trees, &deps.ConfigDeps{}) {
+		got[dep.Name()] = dep
+	}
+
+	for _, want := range []struct {
+		name, version, parent string
+		direct                bool
+		dependents            []string
+	}{
+		{"com.google.guava:guava", "32.1.3-jre", "", true, []string{"com.example:utils"}},
+		{"com.google.guava:failureaccess", "1.0.1", "com.google.guava:guava", false, nil},
+		{"org.checkerframework:checker-qual", "3.41.0", "com.google.guava:guava", true, []string{deps.Root}},
+		{"org.slf4j:slf4j-api", "2.0.9", "", true, nil},
+		{"com.example:utils", "1.0", "", true, nil},
+		{"org.apache.commons:commons-lang3", "3.14.0", "", true, nil},
+	} {
+		dep, ok := got[want.name]
+		if !ok {
+			t.Errorf("%v is not loaded", want.name)
+			continue
+		}
+		if dep.Version != want.version || dep.Parent != want.parent || dep.Direct != want.direct {
+			t.Errorf("%v = {%v %v %v}, want {%v %v %v}", want.name, dep.Version, dep.Parent, dep.Direct, want.version, want.parent, want.direct)
+		}
+		if !reflect.DeepEqual(dep.Dependents, want.dependents) {
+			t.Errorf("%v is brought by %q besides its parent, want %q", want.name, dep.Dependents, want.dependents)
+		}
+	}
+	if len(got) != 6 {
+		t.Errorf("LoadGradleDependenciesTree() loaded %v dependencies, want 6: %v", len(got), got)
+	}
+}
+
+func TestResolveGradleLockfile(t *testing.T) {
+	dir := t.TempDir()
+	home := t.TempDir()
+	t.Setenv("GRADLE_USER_HOME", home)
+
+	writePythonFiles(t, dir, map[string]string{
+		"build.gradle": `dependencies {
+    implementation 'com.example:foo:1.0'
+    testImplementation "junit:junit:4.13.2"
+}
+`,
+		"gradle.lockfile": `# This is a Gradle generated file for dependency locking.
+# Manual edits can break the build and are not advised.
+# This file is expected to be part of source control.
+com.example:bar:2.0=compileClasspath,runtimeClasspath
+com.example:foo:1.0=compileClasspath,runtimeClasspath
+com.example:uncached:1.0=runtimeClasspath
+junit:junit:4.13.2=testCompileClasspath,testRuntimeClasspath
+empty=annotationProcessor
+`,
+	})
+	cache := filepath.Join(home, "caches", "modules-2", "files-2.1")
+	writePythonFiles(t, cache, map[string]string{
+		"com.example/foo/1.0/0a1b2c/foo-1.0.pom": `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <licenses>
+    <license>
+      <name>The Apache Software License, Version 2.0</name>
+      <url>https://www.apache.org/licenses/LICENSE-2.0.txt</url>
+    </license>
+  </licenses>
+</project>
+`,
+		"com.example/bar/2.0/3d4e5f/bar-2.0.pom": `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <licenses>
+    <license>
+      <name>MIT</name>
+    </license>
+  </licenses>
+</project>
+`,
+	})
+
+	report := deps.Report{}
+	if err := new(deps.GradleResolver).Resolve(filepath.Join(dir, "build.gradle"), &deps.ConfigDeps{Threshold: 75}, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved := make(map[string]*deps.Result)
+	for _, r := range report.Resolved {
+		resolved[r.Dependency] = r
+	}
+	if r := resolved["com.example:foo"]; r == nil || r.LicenseSpdxID != "Apache-2.0" || r.Version != "1.0" || !r.Direct {
+		t.Errorf("com.example:foo is resolved as %+v, want Apache-2.0 direct", r)
+	}
+	if r := resolved["com.example:bar"]; r == nil || r.LicenseSpdxID != "MIT" || r.Direct {
+		t.Errorf("com.example:bar is resolved as %+v, want MIT indirect", r)
+	}
+	if len(report.Resolved) != 2 {
+		t.Errorf("resolved %v dependencies, want 2", len(report.Resolved))
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Dependency != "com.example:uncached" {
+		t.Errorf("skipped %+v, want com.example:uncached", report.Skipped)
+	}
+}
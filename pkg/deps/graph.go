@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"slices"
+	"sort"
+)
+
+// Root is the parent of the direct dependencies in the dependency graph, which stands for the project itself.
+const Root = ""
+
+// Depend records that the child dependency is brought by the parent, use Root as the parent of the direct ones.
+func (report *Report) Depend(parent, child string) {
+	if parent == child {
+		return
+	}
+	if report.graph == nil {
+		report.graph = make(map[string][]string)
+	}
+	if !slices.Contains(report.graph[parent], child) {
+		report.graph[parent] = append(report.graph[parent], child)
+	}
+}
+
+// HasGraph returns whether the dependency graph is captured by the resolvers.
+func (report *Report) HasGraph() bool {
+	return len(report.graph) > 0
+}
+
+// Children returns the dependencies brought by the parent, sorted by their names.
+func (report *Report) Children(parent string) []string {
+	children := append([]string{}, report.graph[parent]...)
+	sort.Strings(children)
+	return children
+}
+
+// Paths returns the shortest path from every direct dependency that brings the dependency to the dependency,
+// the paths start with the direct dependencies and end with the dependency.
+func (report *Report) Paths(name string) [][]string {
+	var paths [][]string
+	for _, direct := range report.Children(Root) {
+		if path := report.shortestPath(direct, name); path != nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// IntroducedBy returns the direct dependencies that bring the dependency, which is itself if it's a direct one.
+func (report *Report) IntroducedBy(name string) []string {
+	paths := report.Paths(name)
+	directs := make([]string, len(paths))
+	for i, path := range paths {
+		directs[i] = path[0]
+	}
+	return directs
+}
+
+func (report *Report) shortestPath(from, to string) []string {
+	parents := map[string]string{from: from}
+	for queue := []string{from}; len(queue) > 0; queue = queue[1:] {
+		current := queue[0]
+		if current == to {
+			path := []string{to}
+			for n := to; n != from; n = parents[n] {
+				path = append([]string{parents[n]}, path...)
+			}
+			return path
+		}
+		for _, child := range report.Children(current) {
+			if _, visited := parents[child]; !visited {
+				parents[child] = current
+				queue = append(queue, child)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+func TestPaths(t *testing.T) {
+	report := &deps.Report{}
+	report.Depend(deps.Root, "a")
+	report.Depend(deps.Root, "b")
+	report.Depend(deps.Root, "c")
+	report.Depend("a", "x")
+	report.Depend("x", "y")
+	report.Depend("b", "y")
+	report.Depend("y", "x") // cycle
+
+	if paths := report.Paths("y"); !reflect.DeepEqual(paths, [][]string{{"a", "x", "y"}, {"b", "y"}}) {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+	if paths := report.Paths("c"); !reflect.DeepEqual(paths, [][]string{{"c"}}) {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+	if directs := report.IntroducedBy("x"); !reflect.DeepEqual(directs, []string{"a", "b"}) {
+		t.Errorf("unexpected direct dependencies: %v", directs)
+	}
+	if paths := report.Paths("z"); paths != nil {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+}
+
+func TestResolveGoModuleGraph(t *testing.T) {
+	graph := `example.com/foo github.com/a/direct@v1.0.0
+example.com/foo github.com/b/indirect@v1.0.0
+example.com/foo go@1.23
+github.com/a/direct@v1.0.0 github.com/b/indirect@v1.0.0
+github.com/a/direct@v1.0.0 toolchain@go1.23.6
+`
+	report := &deps.Report{}
+	new(deps.GoModResolver).ResolveGraph([]byte(graph), map[string]bool{"github.com/a/direct": true}, report)
+
+	if children := report.Children(deps.Root); !reflect.DeepEqual(children, []string{"github.com/a/direct"}) {
+		t.Errorf("unexpected direct modules: %v", children)
+	}
+	if paths := report.Paths("github.com/b/indirect"); !reflect.DeepEqual(paths, [][]string{{"github.com/a/direct", "github.com/b/indirect"}}) {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+}
+
+func TestCargoMetadataGraph(t *testing.T) {
+	metadata := &deps.CargoMetadata{
+		Packages: []deps.CargoPackage{
+			{ID: "foo 0.1.0", Name: "foo"},
+			{ID: "serde 1.0.0", Name: "serde"},
+			{ID: "serde_derive 1.0.0", Name: "serde_derive"},
+		},
+		WorkspaceMembers: []string{"foo 0.1.0"},
+		Resolve: &deps.CargoResolve{Nodes: []deps.CargoNode{
+			{ID: "foo 0.1.0", Dependencies: []string{"serde 1.0.0"}},
+			{ID: "serde 1.0.0", Dependencies: []string{"serde_derive 1.0.0"}},
+		}},
+	}
+
+	metadata.MarkDirect()
+	if metadata.Packages[0].Direct || !metadata.Packages[1].Direct || metadata.Packages[2].Direct {
+		t.Errorf("only serde should be direct: %+v", metadata.Packages)
+	}
+
+	report := &deps.Report{}
+	metadata.Depend(report)
+	if paths := report.Paths("serde_derive"); !reflect.DeepEqual(paths, [][]string{{"serde", "serde_derive"}}) {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+}
+
+func TestFind(t *testing.T) {
+	report := &deps.Report{}
+	report.Resolve(&deps.Result{Dependency: "@babel/core", Version: "7.0.0", PURL: "pkg:npm/%40babel/core@7.0.0"})
+	report.Skip(&deps.Result{Dependency: "left-pad", Version: "1.0.0"})
+
+	for _, name := range []string{"@babel/core", "pkg:npm/%40babel/core", "pkg:npm/%40babel/core@7.0.0", "left-pad"} {
+		if results := report.Find(name); len(results) != 1 {
+			t.Errorf("%v should be found, got %v", name, results)
+		}
+	}
+	if results := report.Find("pkg:npm/%40babel/cor"); len(results) != 0 {
+		t.Errorf("unexpected results: %v", results)
+	}
+}
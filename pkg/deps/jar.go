@@ -49,6 +49,7 @@ func (resolver *JarResolver) Resolve(jarFiles string, config *ConfigDeps, report
 		state := NotFound
 		result, err := resolver.ResolveJar(config, &state, jarFile, Unknown)
 		if result != nil {
+			result.Direct = true
 			report.Resolve(result)
 		} else {
 			dep := filepath.Base(jarFile)
@@ -56,6 +57,7 @@ func (resolver *JarResolver) Resolve(jarFiles string, config *ConfigDeps, report
 			report.Skip(&Result{
 				Dependency:    dep,
 				LicenseSpdxID: Unknown,
+				Direct:        true,
 			})
 		}
 	}
@@ -147,12 +147,14 @@ func (resolver *MavenPomResolver) LoadDependencies(config *ConfigDeps) ([]*Depen
 // ResolveDependencies resolves the licenses of the given dependencies
 func (resolver *MavenPomResolver) ResolveDependencies(deps []*Dependency, config *ConfigDeps, report *Report) error {
 	for _, dep := range deps {
+		report.Depend(dep.Parent, dep.Name())
 		func() {
-			if l, ok := config.GetUserConfiguredLicense(dep.Name(), dep.Version); ok {
+			if l, ok := config.GetUserConfiguredLicense(EcosystemMaven, dep.Name(), dep.Version); ok {
 				report.Resolve(&Result{
 					Dependency:    dep.Name(),
 					LicenseSpdxID: l,
 					Version:       dep.Version,
+					Direct:        dep.Direct,
 				})
 				return
 			}
@@ -164,6 +166,7 @@ func (resolver *MavenPomResolver) ResolveDependencies(deps []*Dependency, config
 					Dependency:    dep.Name(),
 					LicenseSpdxID: Unknown,
 					Version:       dep.Version,
+					Direct:        dep.Direct,
 				})
 			}
 		}()
@@ -176,12 +179,14 @@ func (resolver *MavenPomResolver) ResolveLicense(config *ConfigDeps, state *Stat
 	result1, err1 := resolver.ResolveJar(config, state, filepath.Join(resolver.repo, dep.Path(), dep.Jar()), dep.Version)
 	if result1 != nil {
 		result1.Dependency = dep.Name()
+		result1.Direct = dep.Direct
 		report.Resolve(result1)
 		return nil
 	}
 
-	result2, err2 := resolver.ResolveLicenseFromPom(config, state, dep)
+	result2, err2 := resolver.ResolveLicenseFromPom(config, state, dep, filepath.Join(resolver.repo, dep.Path(), dep.Pom()))
 	if result2 != nil {
+		result2.Direct = dep.Direct
 		report.Resolve(result2)
 		return nil
 	}
@@ -190,9 +195,7 @@ func (resolver *MavenPomResolver) ResolveLicense(config *ConfigDeps, state *Stat
 }
 
 // ResolveLicenseFromPom search for license in the pom file, which may appear in the header comments or in license element of xml
-func (resolver *MavenPomResolver) ResolveLicenseFromPom(config *ConfigDeps, state *State, dep *Dependency) (*Result, error) {
-	pomFile := filepath.Join(resolver.repo, dep.Path(), dep.Pom())
-
+func (resolver *MavenPomResolver) ResolveLicenseFromPom(config *ConfigDeps, state *State, dep *Dependency, pomFile string) (*Result, error) {
 	pom, err := resolver.ReadLicensesFromPom(pomFile)
 	if err != nil {
 		return nil, err
@@ -286,8 +289,12 @@ func SeemLicense(content string) bool {
 }
 
 func LoadDependencies(data []byte, config *ConfigDeps) []*Dependency {
-	depsTree := LoadDependenciesTree(data)
+	return FlattenDependencies(LoadDependenciesTree(data), config)
+}
 
+// FlattenDependencies flattens the dependency trees into a list, leaving out the excluded dependencies
+// (and their transitive dependencies if the exclusion is recursive).
+func FlattenDependencies(depsTree []*Dependency, config *ConfigDeps) []*Dependency {
 	cnt := 0
 	for _, dep := range depsTree {
 		cnt += dep.Count()
@@ -297,7 +304,7 @@ func LoadDependencies(data []byte, config *ConfigDeps) []*Dependency {
 
 	queue := []*Dependency{}
 	for _, depTree := range depsTree {
-		if exclude, recursive := config.IsExcluded(depTree.Name(), depTree.Version); !exclude {
+		if exclude, recursive := config.IsExcluded(EcosystemMaven, depTree.Name(), depTree.Version); !exclude {
 			queue = append(queue, depTree)
 		} else if recursive {
 			continue
@@ -309,7 +316,7 @@ func LoadDependencies(data []byte, config *ConfigDeps) []*Dependency {
 			dep := queue[0]
 			queue = queue[1:]
 
-			exclude, recursive := config.IsExcluded(dep.Name(), dep.Version)
+			exclude, recursive := config.IsExcluded(EcosystemMaven, dep.Name(), dep.Version)
 			if exclude && recursive {
 				continue
 			}
@@ -364,6 +371,7 @@ func LoadDependenciesTree(data []byte) []*Dependency {
 		dependence := string(rawDep[2])
 
 		if level == 0 {
+			dep.Direct = true
 			deps = append(deps, dep)
 
 			if len(stack) != 0 {
@@ -378,9 +386,11 @@ func LoadDependenciesTree(data []byte) []*Dependency {
 
 		if level == tail.level {
 			stack[len(stack)-1] = Elem{dep, level}
+			dep.Parent = stack[len(stack)-2].Name()
 			stack[len(stack)-2].TransitiveDeps = append(stack[len(stack)-2].TransitiveDeps, dep)
 		} else {
 			stack = append(stack, Elem{dep, level})
+			dep.Parent = tail.Name()
 			tail.TransitiveDeps = append(tail.TransitiveDeps, dep)
 		}
 
@@ -423,6 +433,13 @@ func (s *State) String() string {
 type Dependency struct {
 	GroupID, ArtifactID, Version, Packaging, Scope string
 	TransitiveDeps                                 []*Dependency
+	// Direct is true if the dependency is declared directly in the pom file.
+	Direct bool
+	// Parent is the name of the dependency that brings this one, empty for the direct ones.
+	Parent string
+	// Dependents are the names of the other dependencies that bring this one, the dependency is only listed once in
+	// the trees, under the Parent.
+	Dependents []string
 }
 
 func (dep *Dependency) Clone() *Dependency {
@@ -432,6 +449,9 @@ func (dep *Dependency) Clone() *Dependency {
 		Version:    dep.Version,
 		Packaging:  dep.Packaging,
 		Scope:      dep.Scope,
+		Direct:     dep.Direct,
+		Parent:     dep.Parent,
+		Dependents: dep.Dependents,
 	}
 }
 
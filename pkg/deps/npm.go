@@ -26,6 +26,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -46,25 +48,63 @@ type Lcs struct {
 // Package represents package.json
 // License field has inconsistent styles, so we just store the byte array here to postpone unmarshalling
 type Package struct {
-	Name     string          `json:"name"`
-	License  json.RawMessage `json:"license"`
-	Licenses []Lcs           `json:"licenses"`
-	Path     string          `json:"-"`
-	Version  string          `json:"version"`
+	Name                 string            `json:"name"`
+	License              json.RawMessage   `json:"license"`
+	Licenses             []Lcs             `json:"licenses"`
+	Path                 string            `json:"-"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// DependsOn returns whether the package declares the production dependency directly.
+func (pkg *Package) DependsOn(name string) bool {
+	_, ok := pkg.Dependencies[name]
+	if !ok {
+		_, ok = pkg.OptionalDependencies[name]
+	}
+	if !ok {
+		_, ok = pkg.PeerDependencies[name]
+	}
+	return ok
+}
+
+// RuntimeDependencies returns the names of the production dependencies that the package declares, sorted by names.
+func (pkg *Package) RuntimeDependencies() []string {
+	var names []string
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies, pkg.PeerDependencies} {
+		for name := range deps {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 const PkgFileName = "package.json"
 
-// CanResolve checks whether the given file is the npm package file
+// CanResolve checks whether the given file is the npm package file, or the npm, Yarn or pnpm lockfile
 func (resolver *NpmResolver) CanResolve(file string) bool {
 	base := filepath.Base(file)
 	logger.Log.Debugln("Base name:", base)
-	return base == PkgFileName
+	return base == PkgFileName || base == PkgLockFileName || base == YarnLockFileName || base == PnpmLockFileName
 }
 
-// Resolve resolves licenses of all dependencies declared in the package.json file.
+// Resolve resolves licenses of all dependencies declared in the package.json file,
+// or locked in the lockfile, which doesn't install the packages.
 func (resolver *NpmResolver) Resolve(pkgFile string, config *ConfigDeps, report *Report) error {
+	if filepath.Base(pkgFile) != PkgFileName {
+		return resolver.ResolveLockfile(pkgFile, config, report)
+	}
+
 	workDir := filepath.Dir(pkgFile)
+	root, err := resolver.ParsePkgFile(pkgFile)
+	if err != nil {
+		return err
+	}
 	if err := os.Chdir(workDir); err != nil {
 		return err
 	}
@@ -82,10 +122,24 @@ func (resolver *NpmResolver) Resolve(pkgFile string, config *ConfigDeps, report
 	pkgDir := filepath.Join(workDir, "node_modules")
 	pkgs := resolver.GetInstalledPkgs(pkgDir)
 
+	installed := make(map[string]bool)
+	for _, pkg := range pkgs {
+		installed[pkg.Name] = true
+	}
+
 	// Walk through each package's root directory to resolve licenses
 	// Resolve from a package's package.json file or its license file
 	for _, pkg := range pkgs {
-		if result := resolver.ResolvePackageLicense(pkg.Name, pkg.Path, config); result.LicenseSpdxID != "" {
+		if info, err := resolver.ParsePkgFile(filepath.Join(pkg.Path, PkgFileName)); err == nil {
+			for _, dep := range info.RuntimeDependencies() {
+				if installed[dep] {
+					report.Depend(pkg.Name, dep)
+				}
+			}
+		}
+		result := resolver.ResolvePackageLicense(pkg.Name, pkg.Path, config)
+		result.Direct = root.DependsOn(pkg.Name)
+		if result.LicenseSpdxID != "" {
 			report.Resolve(result)
 		} else {
 			result.LicenseSpdxID = Unknown
@@ -220,7 +274,7 @@ func (resolver *NpmResolver) ResolvePkgFile(result *Result, pkgPath string, conf
 	}
 
 	result.Version = packageInfo.Version
-	if l, ok := config.GetUserConfiguredLicense(packageInfo.Name, packageInfo.Version); ok {
+	if l, ok := config.GetUserConfiguredLicense(EcosystemNpm, packageInfo.Name, packageInfo.Version); ok {
 		result.LicenseSpdxID = l
 		return nil
 	}
@@ -292,7 +346,7 @@ func (resolver *NpmResolver) ResolveLcsFile(result *Result, pkgPath string, conf
 		if result.LicenseSpdxID != "" {
 			return nil
 		}
-		if l, ok := config.GetUserConfiguredLicense(info.Name(), result.Version); ok {
+		if l, ok := config.GetUserConfiguredLicense(EcosystemNpm, info.Name(), result.Version); ok {
 			result.LicenseSpdxID = l
 			return nil
 		}
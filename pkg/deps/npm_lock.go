@@ -0,0 +1,555 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+)
+
+// The lockfiles that NpmResolver resolves without installing the packages.
+const (
+	PkgLockFileName  = "package-lock.json"
+	YarnLockFileName = "yarn.lock"
+	PnpmLockFileName = "pnpm-lock.yaml"
+)
+
+// NpmLockPackage is a production package in the npm, Yarn or pnpm lockfile.
+type NpmLockPackage struct {
+	Name    string
+	Version string
+	// License is the license recorded in the lockfile, only package-lock.json records it.
+	License string
+	// Path is the directory of the package relative to the lockfile, only package-lock.json records it.
+	Path         string
+	Direct       bool
+	Dependencies []string
+}
+
+// npmLockNode is a package in the dependency graph of the Yarn and pnpm lockfiles, which are keyed by the descriptors.
+type npmLockNode struct {
+	Name    string
+	Version string
+	// Local is true for the workspace packages, which are part of the project.
+	Local        bool
+	Dependencies []string
+}
+
+// ResolveLockfile resolves licenses of the production dependencies in the lockfile, without installing the packages.
+// The licenses are read from the packages in node_modules if they're installed, or from the lockfile.
+func (resolver *NpmResolver) ResolveLockfile(lockFile string, config *ConfigDeps, report *Report) error {
+	pkgs, err := resolver.LoadLockfile(lockFile)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(lockFile)
+	for _, pkg := range pkgs {
+		for _, dep := range pkg.Dependencies {
+			report.Depend(pkg.Name, dep)
+		}
+
+		if exclude, _ := config.IsExcluded(EcosystemNpm, pkg.Name, pkg.Version); exclude {
+			continue
+		}
+		if l, ok := config.GetUserConfiguredLicense(EcosystemNpm, pkg.Name, pkg.Version); ok {
+			report.Resolve(&Result{Dependency: pkg.Name, LicenseSpdxID: l, Version: pkg.Version, Direct: pkg.Direct})
+			continue
+		}
+
+		result := resolver.ResolveLockPackageLicense(dir, pkg, config)
+		result.Direct = pkg.Direct
+		if result.LicenseSpdxID != "" {
+			report.Resolve(result)
+		} else {
+			result.LicenseSpdxID = Unknown
+			report.Skip(result)
+			logger.Log.Warnln("Failed to resolve the license of dependency:", pkg.Name, result.ResolveErrors)
+		}
+	}
+	return nil
+}
+
+// ResolveLockPackageLicense resolves the license of the package from its directory in node_modules if it's installed,
+// or from the license recorded in the lockfile.
+func (resolver *NpmResolver) ResolveLockPackageLicense(dir string, pkg *NpmLockPackage, config *ConfigDeps) *Result {
+	var errs []error
+	if pkgPath := resolver.InstalledPkgPath(dir, pkg); pkgPath != "" {
+		result := resolver.ResolvePackageLicense(pkg.Name, pkgPath, config)
+		if result.LicenseSpdxID != "" {
+			result.Version = pkg.Version
+			return result
+		}
+		errs = result.ResolveErrors
+	}
+
+	result := &Result{Dependency: pkg.Name, Version: pkg.Version}
+	if pkg.License != "" {
+		result.LicenseSpdxID = pkg.License
+	} else {
+		result.ResolveErrors = append(errs, fmt.Errorf("no license in the lockfile, and the package is not installed"))
+	}
+	return result
+}
+
+// InstalledPkgPath returns the directory of the package in node_modules if the same version is installed,
+// or an empty string.
+func (resolver *NpmResolver) InstalledPkgPath(dir string, pkg *NpmLockPackage) string {
+	candidates := []string{
+		filepath.Join(dir, "node_modules", filepath.FromSlash(pkg.Name)),
+		filepath.Join(dir, "node_modules", ".pnpm", strings.ReplaceAll(pkg.Name, "/", "+")+"@"+pkg.Version,
+			"node_modules", filepath.FromSlash(pkg.Name)),
+	}
+	if pkg.Path != "" {
+		candidates = append([]string{filepath.Join(dir, filepath.FromSlash(pkg.Path))}, candidates...)
+	}
+	for _, candidate := range candidates {
+		if info, err := resolver.ParsePkgFile(filepath.Join(candidate, PkgFileName)); err == nil && info.Version == pkg.Version {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// LoadLockfile loads the production packages from the lockfile, the direct ones are those declared in the
+// dependencies, optionalDependencies and peerDependencies of the project.
+func (resolver *NpmResolver) LoadLockfile(lockFile string) ([]*NpmLockPackage, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*NpmLockPackage
+	switch filepath.Base(lockFile) {
+	case PkgLockFileName:
+		pkgs, err = resolver.ParsePackageLock(content)
+	case PnpmLockFileName:
+		pkgs, err = parsePnpmLock(content)
+	default:
+		var root *Package
+		if root, err = resolver.ParsePkgFile(filepath.Join(filepath.Dir(lockFile), PkgFileName)); err != nil {
+			return nil, err
+		}
+		pkgs, err = parseYarnLock(content, root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", lockFile, err)
+	}
+	return pkgs, nil
+}
+
+// ParsePackageLock parses package-lock.json (lockfileVersion 2 and 3), which flags the dev dependencies and
+// records the licenses of the packages.
+func (resolver *NpmResolver) ParsePackageLock(content []byte) ([]*NpmLockPackage, error) {
+	var lock struct {
+		LockfileVersion int `json:"lockfileVersion"`
+		Packages        map[string]struct {
+			Package
+			Dev         bool `json:"dev"`
+			DevOptional bool `json:"devOptional"`
+			Link        bool `json:"link"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	if lock.LockfileVersion < 2 || lock.Packages == nil {
+		return nil, fmt.Errorf("lockfileVersion %v is not supported, upgrade it with `npm install --lockfile-version 3`", lock.LockfileVersion)
+	}
+
+	paths := make([]string, 0, len(lock.Packages))
+	for path := range lock.Packages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// the dependencies of the project and its workspaces
+	direct := make(map[string]bool)
+	for _, path := range paths {
+		if entry := lock.Packages[path]; !strings.Contains(path, "node_modules/") {
+			for _, dep := range entry.RuntimeDependencies() {
+				direct[dep] = true
+			}
+		}
+	}
+
+	var pkgs []*NpmLockPackage
+	for _, path := range paths {
+		entry := lock.Packages[path]
+		i := strings.LastIndex(path, "node_modules/")
+		if i < 0 || entry.Dev || entry.DevOptional || entry.Link {
+			continue
+		}
+		name := path[i+len("node_modules/"):]
+		if entry.Name != "" {
+			name = entry.Name
+		}
+		pkg := &NpmLockPackage{
+			Name:         name,
+			Version:      entry.Version,
+			Path:         path,
+			Direct:       i == 0 && direct[name],
+			Dependencies: entry.RuntimeDependencies(),
+		}
+		if lcs, ok := resolver.ResolveLicenseField(entry.License); ok {
+			pkg.License = lcs
+		} else if lcs, ok := resolver.ResolveLicensesField(entry.Licenses); ok {
+			pkg.License = lcs
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+// parseYarnLock parses yarn.lock of Yarn classic (v1) or Yarn berry (v2+), which don't flag the dev dependencies,
+// so the production packages are those reachable from the production dependencies of the project.
+func parseYarnLock(content []byte, root *Package) ([]*NpmLockPackage, error) {
+	var nodes map[string]*npmLockNode
+	if strings.Contains(string(content), "\n__metadata:") || strings.HasPrefix(string(content), "__metadata:") {
+		var err error
+		if nodes, err = parseYarnBerryLock(content); err != nil {
+			return nil, err
+		}
+	} else {
+		nodes = parseYarnClassicLock(string(content))
+	}
+
+	var roots []string
+	for _, name := range root.RuntimeDependencies() {
+		version := root.Dependencies[name]
+		if v, ok := root.OptionalDependencies[name]; ok {
+			version = v
+		} else if v, ok := root.PeerDependencies[name]; ok && version == "" {
+			version = v
+		}
+		roots = append(roots, yarnDescriptor(nodes, name, version))
+	}
+	return selectNpmLockPackages(nodes, roots), nil
+}
+
+// yarnDescriptor returns the descriptor of the dependency in the yarn.lock, where the npm protocol is explicit in
+// Yarn berry.
+func yarnDescriptor(nodes map[string]*npmLockNode, name, version string) string {
+	descriptor := name + "@" + version
+	if _, ok := nodes[descriptor]; !ok {
+		return name + "@npm:" + version
+	}
+	return descriptor
+}
+
+// parseYarnClassicLock parses the yarn.lock of Yarn classic, which is in its own format, such as:
+//
+//	"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+//	  version "7.12.13"
+//	  dependencies:
+//	    "@babel/highlight" "^7.12.13"
+func parseYarnClassicLock(content string) map[string]*npmLockNode {
+	nodes := make(map[string]*npmLockNode)
+	var node *npmLockNode
+	var dependencies bool
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent == 0 && strings.HasSuffix(trimmed, ":"):
+			node = &npmLockNode{}
+			for _, descriptor := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				descriptor = unquoteYarn(strings.TrimSpace(descriptor))
+				node.Name, _, _ = cutNpmVersion(descriptor)
+				nodes[descriptor] = node
+			}
+		case node == nil:
+		case indent == 2:
+			key, value := yarnField(trimmed)
+			dependencies = key == "dependencies:" || key == "optionalDependencies:"
+			if key == "version" {
+				node.Version = value
+			}
+		case indent == 4 && dependencies:
+			name, version := yarnField(trimmed)
+			node.Dependencies = append(node.Dependencies, name+"@"+version)
+		}
+	}
+	return nodes
+}
+
+// yarnField splits the line of yarn.lock into the (quoted) key and value.
+func yarnField(line string) (key, value string) {
+	if strings.HasPrefix(line, `"`) {
+		if i := strings.Index(line[1:], `"`); i >= 0 {
+			return line[1 : i+1], unquoteYarn(strings.TrimSpace(line[i+2:]))
+		}
+	}
+	key, value, _ = strings.Cut(line, " ")
+	return key, unquoteYarn(strings.TrimSpace(value))
+}
+
+func unquoteYarn(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
+
+// parseYarnBerryLock parses the yarn.lock of Yarn berry, which is a YAML file.
+func parseYarnBerryLock(content []byte) (map[string]*npmLockNode, error) {
+	var lock map[string]struct {
+		Version              string            `yaml:"version"`
+		Resolution           string            `yaml:"resolution"`
+		Dependencies         map[string]string `yaml:"dependencies"`
+		OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+		LinkType             string            `yaml:"linkType"`
+	}
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*npmLockNode)
+	for descriptors, entry := range lock {
+		if descriptors == "__metadata" {
+			continue
+		}
+		node := &npmLockNode{Version: entry.Version, Local: entry.LinkType == "soft"}
+		node.Name, _, _ = cutNpmVersion(entry.Resolution)
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			for name, version := range deps {
+				node.Dependencies = append(node.Dependencies, name+"@"+version)
+			}
+		}
+		sort.Strings(node.Dependencies)
+		for _, descriptor := range strings.Split(descriptors, ",") {
+			nodes[strings.TrimSpace(descriptor)] = node
+		}
+	}
+
+	// the dependencies in the lockfile omit the default npm protocol
+	for _, node := range nodes {
+		for i, dep := range node.Dependencies {
+			if _, ok := nodes[dep]; !ok {
+				if name, version, ok := cutNpmVersion(dep); ok {
+					node.Dependencies[i] = name + "@npm:" + version
+				}
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// pnpmVersion is the version of the dependency in pnpm-lock.yaml, which is a plain version before lockfileVersion
+// 6.0, and a mapping of the specifier and the version since then.
+type pnpmVersion string
+
+func (v *pnpmVersion) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		*v = pnpmVersion(node.Value)
+		return nil
+	}
+	var dep struct {
+		Version string `yaml:"version"`
+	}
+	if err := node.Decode(&dep); err != nil {
+		return err
+	}
+	*v = pnpmVersion(dep.Version)
+	return nil
+}
+
+type pnpmDependencies struct {
+	Dependencies         map[string]pnpmVersion `yaml:"dependencies"`
+	OptionalDependencies map[string]pnpmVersion `yaml:"optionalDependencies"`
+}
+
+// parsePnpmLock parses pnpm-lock.yaml, the production packages are those reachable from the production
+// dependencies of the importers (the project and its workspaces).
+func parsePnpmLock(content []byte) ([]*NpmLockPackage, error) {
+	var lock struct {
+		pnpmDependencies `yaml:",inline"`
+		LockfileVersion  string                      `yaml:"lockfileVersion"`
+		Importers        map[string]pnpmDependencies `yaml:"importers"`
+		Packages         map[string]pnpmDependencies `yaml:"packages"`
+		Snapshots        map[string]pnpmDependencies `yaml:"snapshots"`
+	}
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	major, _ := strconv.Atoi(strings.SplitN(lock.LockfileVersion, ".", 2)[0])
+	// the keys of the packages are "/name/version" before 6.0, "/name@version" before 9.0 and "name@version" since 9.0
+	key := func(name string, version pnpmVersion) string {
+		v := string(version)
+		switch {
+		case strings.HasPrefix(v, "link:") || strings.HasPrefix(v, "file:"):
+			return ""
+		case major >= 9 && strings.LastIndex(strings.SplitN(v, "(", 2)[0], "@") > 0, major < 9 && strings.HasPrefix(v, "/"):
+			return v // aliased to another package
+		case major >= 9:
+			return name + "@" + v
+		case major >= 6:
+			return "/" + name + "@" + v
+		default:
+			return "/" + name + "/" + v
+		}
+	}
+
+	packages := lock.Snapshots
+	if major < 9 || packages == nil {
+		packages = lock.Packages
+	}
+	nodes := make(map[string]*npmLockNode, len(packages))
+	for k, entry := range packages {
+		node := &npmLockNode{}
+		node.Name, node.Version = splitPnpmKey(k, major)
+		for _, deps := range []map[string]pnpmVersion{entry.Dependencies, entry.OptionalDependencies} {
+			for name, version := range deps {
+				if dep := key(name, version); dep != "" {
+					node.Dependencies = append(node.Dependencies, dep)
+				}
+			}
+		}
+		sort.Strings(node.Dependencies)
+		nodes[k] = node
+	}
+
+	importers := lock.Importers
+	if importers == nil {
+		importers = map[string]pnpmDependencies{".": lock.pnpmDependencies}
+	}
+	var roots []string
+	for _, importer := range importers {
+		for _, deps := range []map[string]pnpmVersion{importer.Dependencies, importer.OptionalDependencies} {
+			for name, version := range deps {
+				if root := key(name, version); root != "" {
+					roots = append(roots, root)
+				}
+			}
+		}
+	}
+	sort.Strings(roots)
+	return selectNpmLockPackages(nodes, roots), nil
+}
+
+// splitPnpmKey splits the key of the package in pnpm-lock.yaml into the name and the version without the peer
+// dependencies suffix.
+func splitPnpmKey(key string, major int) (name, version string) {
+	key = strings.TrimPrefix(key, "/")
+	if major < 6 {
+		key, _, _ = strings.Cut(key, "_")
+		if i := strings.LastIndex(key, "/"); i > 0 {
+			return key[:i], key[i+1:]
+		}
+		return key, ""
+	}
+	key, _, _ = strings.Cut(key, "(")
+	if i := strings.LastIndex(key, "@"); i > 0 {
+		return key[:i], key[i+1:]
+	}
+	return key, ""
+}
+
+// cutNpmVersion cuts the "name@version" at the first "@" that is not the leading one of the scoped packages.
+func cutNpmVersion(s string) (name, version string, ok bool) {
+	if i := strings.Index(s[min(1, len(s)):], "@"); i >= 0 {
+		return s[:i+1], s[i+2:], true
+	}
+	return s, "", false
+}
+
+// selectNpmLockPackages selects the packages reachable from the roots, the packages that the roots and the
+// workspace packages depend on are direct.
+func selectNpmLockPackages(nodes map[string]*npmLockNode, roots []string) []*NpmLockPackage {
+	type elem struct {
+		key    string
+		direct bool
+	}
+	queue := make([]elem, 0, len(roots))
+	for _, root := range roots {
+		queue = append(queue, elem{root, true})
+	}
+
+	visited := make(map[string]bool)
+	selected := make(map[string]*NpmLockPackage)
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		node, ok := nodes[e.key]
+		if !ok || node.Name == "" {
+			continue
+		}
+		id := node.Name + "@" + node.Version
+		if pkg, ok := selected[id]; ok {
+			pkg.Direct = pkg.Direct || e.direct
+		}
+		if visited[e.key] {
+			continue
+		}
+		visited[e.key] = true
+
+		if !node.Local {
+			pkg, ok := selected[id]
+			if !ok {
+				pkg = &NpmLockPackage{Name: node.Name, Version: node.Version, Direct: e.direct}
+				selected[id] = pkg
+			}
+			for _, dep := range node.Dependencies {
+				if depNode, ok := nodes[dep]; ok && depNode.Name != "" && !depNode.Local {
+					pkg.Dependencies = appendUnique(pkg.Dependencies, depNode.Name)
+				}
+			}
+		}
+		for _, dep := range node.Dependencies {
+			queue = append(queue, elem{dep, node.Local})
+		}
+	}
+
+	pkgs := make([]*NpmLockPackage, 0, len(selected))
+	for _, pkg := range selected {
+		sort.Strings(pkg.Dependencies)
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		if pkgs[i].Name != pkgs[j].Name {
+			return pkgs[i].Name < pkgs[j].Name
+		}
+		return pkgs[i].Version < pkgs[j].Version
+	})
+	return pkgs
+}
+
+func appendUnique(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
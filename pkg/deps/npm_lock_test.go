@@ -0,0 +1,297 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+const npmRootPkg = `{
+  "name": "demo",
+  "version": "1.0.0",
+  "dependencies": {"@scope/a": "^1.0.0", "b": "~2.0.0"},
+  "devDependencies": {"jest": "^29.0.0"}
+}`
+
+// describeNpmLockPackages describes the packages as "name@version[*] -> dependencies", where * marks the direct ones.
+func describeNpmLockPackages(pkgs []*deps.NpmLockPackage) string {
+	lines := make([]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		direct := ""
+		if pkg.Direct {
+			direct = "*"
+		}
+		lines = append(lines, fmt.Sprintf("%v@%v%v -> %v", pkg.Name, pkg.Version, direct, strings.Join(pkg.Dependencies, ",")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestLoadNpmLockfiles(t *testing.T) {
+	const want = `@scope/a@1.2.0* -> c
+b@2.0.1* -> 
+c@3.0.0 -> `
+
+	for _, test := range []struct {
+		name, lockfile, content string
+	}{
+		{"yarn classic", "yarn.lock", `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@scope/a@^1.0.0":
+  version "1.2.0"
+  resolved "https://registry.yarnpkg.com/@scope/a/-/a-1.2.0.tgz"
+  dependencies:
+    c "^3.0.0"
+
+b@~2.0.0:
+  version "2.0.1"
+
+c@^3.0.0, c@^3.0.0-rc:
+  version "3.0.0"
+
+jest@^29.0.0:
+  version "29.7.0"
+  dependencies:
+    d "^1.0.0"
+
+d@^1.0.0:
+  version "1.0.0"
+`},
+		{"yarn berry", "yarn.lock", `# This file is generated by running "yarn install" inside your project.
+
+__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@scope/a@npm:^1.0.0":
+  version: 1.2.0
+  resolution: "@scope/a@npm:1.2.0"
+  dependencies:
+    c: "npm:^3.0.0"
+  checksum: 10c0/0
+  languageName: node
+  linkType: hard
+
+"b@npm:~2.0.0":
+  version: 2.0.1
+  resolution: "b@npm:2.0.1"
+  languageName: node
+  linkType: hard
+
+"c@npm:^3.0.0":
+  version: 3.0.0
+  resolution: "c@npm:3.0.0"
+  languageName: node
+  linkType: hard
+
+"demo@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "demo@workspace:."
+  dependencies:
+    "@scope/a": "npm:^1.0.0"
+    b: "npm:~2.0.0"
+    jest: "npm:^29.0.0"
+  languageName: unknown
+  linkType: soft
+
+"jest@npm:^29.0.0":
+  version: 29.7.0
+  resolution: "jest@npm:29.7.0"
+  languageName: node
+  linkType: hard
+`},
+		{"pnpm v9", "pnpm-lock.yaml", `lockfileVersion: '9.0'
+
+importers:
+  .:
+    dependencies:
+      '@scope/a':
+        specifier: ^1.0.0
+        version: 1.2.0(c@3.0.0)
+      b:
+        specifier: ~2.0.0
+        version: 2.0.1
+    devDependencies:
+      jest:
+        specifier: ^29.0.0
+        version: 29.7.0
+
+packages:
+  '@scope/a@1.2.0':
+    resolution: {integrity: sha512-0}
+  b@2.0.1:
+    resolution: {integrity: sha512-0}
+  c@3.0.0:
+    resolution: {integrity: sha512-0}
+  jest@29.7.0:
+    resolution: {integrity: sha512-0}
+
+snapshots:
+  '@scope/a@1.2.0(c@3.0.0)':
+    dependencies:
+      c: 3.0.0
+  b@2.0.1: {}
+  c@3.0.0: {}
+  jest@29.7.0: {}
+`},
+		{"pnpm v6", "pnpm-lock.yaml", `lockfileVersion: '6.0'
+
+dependencies:
+  '@scope/a':
+    specifier: ^1.0.0
+    version: 1.2.0
+  b:
+    specifier: ~2.0.0
+    version: 2.0.1
+
+devDependencies:
+  jest:
+    specifier: ^29.0.0
+    version: 29.7.0
+
+packages:
+  /@scope/a@1.2.0:
+    resolution: {integrity: sha512-0}
+    dependencies:
+      c: 3.0.0
+    dev: false
+  /b@2.0.1:
+    dev: false
+  /c@3.0.0:
+    dev: false
+  /jest@29.7.0:
+    dev: true
+`},
+		{"pnpm v5", "pnpm-lock.yaml", `lockfileVersion: 5.4
+
+specifiers:
+  '@scope/a': ^1.0.0
+  b: ~2.0.0
+  jest: ^29.0.0
+
+dependencies:
+  '@scope/a': 1.2.0
+  b: 2.0.1
+
+devDependencies:
+  jest: 29.7.0
+
+packages:
+  /@scope/a/1.2.0:
+    dependencies:
+      c: 3.0.0
+    dev: false
+  /b/2.0.1:
+    dev: false
+  /c/3.0.0:
+    dev: false
+  /jest/29.7.0:
+    dev: true
+`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writePythonFiles(t, dir, map[string]string{"package.json": npmRootPkg, test.lockfile: test.content})
+			pkgs, err := new(deps.NpmResolver).LoadLockfile(filepath.Join(dir, test.lockfile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := describeNpmLockPackages(pkgs); got != want {
+				t.Errorf("LoadLockfile() =\n%v\nwant\n%v", got, want)
+			}
+		})
+	}
+}
+
+func TestResolvePackageLock(t *testing.T) {
+	dir := t.TempDir()
+	writePythonFiles(t, dir, map[string]string{
+		"package.json": npmRootPkg,
+		"package-lock.json": `{
+  "name": "demo",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "": {
+      "name": "demo",
+      "version": "1.0.0",
+      "dependencies": {"@scope/a": "^1.0.0", "b": "~2.0.0"},
+      "devDependencies": {"jest": "^29.0.0"}
+    },
+    "node_modules/@scope/a": {
+      "version": "1.2.0",
+      "license": "Apache-2.0",
+      "dependencies": {"c": "^3.0.0"}
+    },
+    "node_modules/@scope/a/node_modules/c": {
+      "version": "3.0.0",
+      "license": "ISC"
+    },
+    "node_modules/b": {
+      "version": "2.0.1"
+    },
+    "node_modules/c": {
+      "version": "1.0.0",
+      "dev": true,
+      "license": "MIT"
+    },
+    "node_modules/fsevents": {
+      "version": "2.3.3",
+      "devOptional": true,
+      "license": "MIT"
+    },
+    "node_modules/jest": {
+      "version": "29.7.0",
+      "dev": true,
+      "license": "MIT",
+      "dependencies": {"c": "^1.0.0"}
+    }
+  }
+}`,
+		"node_modules/b/package.json": `{"name": "b", "version": "2.0.1"}`,
+		"node_modules/b/LICENSE":      mitLicense,
+	})
+
+	report := deps.Report{}
+	config := &deps.ConfigDeps{Threshold: 75, Files: []string{filepath.Join(dir, "package-lock.json")}}
+	if err := deps.Resolve(config, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range report.Resolved {
+		got = append(got, fmt.Sprintf("%v@%v %v %v", r.Dependency, r.Version, r.LicenseSpdxID, r.Direct))
+	}
+	want := []string{"@scope/a@1.2.0 Apache-2.0 true", "c@3.0.0 ISC false", "b@2.0.1 MIT true"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("resolved\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(report.Skipped) != 0 {
+		t.Errorf("skipped %+v, want none", report.Skipped)
+	}
+	if paths := report.Paths("c"); len(paths) != 1 || strings.Join(paths[0], " -> ") != "@scope/a -> c" {
+		t.Errorf("Paths(c) = %v, want [[@scope/a c]]", paths)
+	}
+}
@@ -0,0 +1,348 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// NuGetResolver resolves .NET dependencies from packages.lock.json or obj/project.assets.json, the licenses are read
+// from the .nuspec files of the packages in the global packages folder ($NUGET_PACKAGES, defaults to ~/.nuget/packages,
+// or the package folders recorded in project.assets.json), so the project must have been restored.
+type NuGetResolver struct {
+	Resolver
+}
+
+const (
+	nugetPackagesLock = "packages.lock.json"
+	nugetAssets       = "project.assets.json"
+	nugetProjectExt   = ".csproj"
+	nugetLicenseHost  = "licenses.nuget.org"
+)
+
+// NuGetPackage is a package that the .NET project depends on.
+type NuGetPackage struct {
+	Name         string
+	Version      string
+	Direct       bool
+	Dependencies []string
+}
+
+// Nuspec is the .nuspec file of the NuGet package.
+type Nuspec struct {
+	XMLName  xml.Name `xml:"package"`
+	Metadata struct {
+		ID      string `xml:"id"`
+		Version string `xml:"version"`
+		License struct {
+			Type  string `xml:"type,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"license"`
+		LicenseURL string `xml:"licenseUrl"`
+	} `xml:"metadata"`
+}
+
+// CanResolve determines whether the file is packages.lock.json, project.assets.json or a .csproj project file.
+func (resolver *NuGetResolver) CanResolve(file string) bool {
+	base := filepath.Base(file)
+	return base == nugetPackagesLock || base == nugetAssets || filepath.Ext(base) == nugetProjectExt
+}
+
+// Resolve resolves licenses of all the packages in packages.lock.json or project.assets.json, the packages of a .csproj
+// project file are read from the packages.lock.json beside it, or the obj/project.assets.json written by the restore.
+func (resolver *NuGetResolver) Resolve(file string, config *ConfigDeps, report *Report) error {
+	if filepath.Ext(file) == nugetProjectExt {
+		dir := filepath.Dir(file)
+		if file = filepath.Join(dir, nugetPackagesLock); !fileExists(file) {
+			file = filepath.Join(dir, "obj", nugetAssets)
+		}
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var pkgs []*NuGetPackage
+	var folders []string
+	if filepath.Base(file) == nugetAssets {
+		pkgs, folders, err = parseNuGetAssets(content)
+	} else {
+		pkgs, err = parseNuGetPackagesLock(content)
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %w", file, err)
+	}
+	folders = append(folders, NuGetPackagesFolder())
+
+	for _, pkg := range pkgs {
+		for _, dep := range pkg.Dependencies {
+			report.Depend(pkg.Name, dep)
+		}
+
+		if exclude, _ := config.IsExcluded(EcosystemNuGet, pkg.Name, pkg.Version); exclude {
+			continue
+		}
+		if l, ok := config.GetUserConfiguredLicense(EcosystemNuGet, pkg.Name, pkg.Version); ok {
+			report.Resolve(&Result{Dependency: pkg.Name, LicenseSpdxID: l, Version: pkg.Version, Direct: pkg.Direct})
+			continue
+		}
+
+		result, err := resolver.ResolvePackageLicense(config, pkg, folders)
+		if err != nil {
+			logger.Log.Warnf("Failed to resolve the license of <%s@%s>: %v\n", pkg.Name, pkg.Version, err)
+			report.Skip(&Result{
+				Dependency:    pkg.Name,
+				LicenseSpdxID: Unknown,
+				Version:       pkg.Version,
+				Direct:        pkg.Direct,
+				ResolveErrors: []error{err},
+			})
+			continue
+		}
+		result.Direct = pkg.Direct
+		report.Resolve(result)
+	}
+	return nil
+}
+
+// ResolvePackageLicense resolves the license of the package from its .nuspec file, which declares the license
+// expression, or the license file embedded in the package, or the (deprecated) license URL. The license files in the
+// package are identified if the .nuspec declares none of them.
+func (resolver *NuGetResolver) ResolvePackageLicense(config *ConfigDeps, pkg *NuGetPackage, folders []string) (*Result, error) {
+	dir := NuGetPackageDir(folders, pkg)
+	if dir == "" {
+		return nil, fmt.Errorf("the package is not found in the global packages folders %v, restore the project first", folders)
+	}
+	nuspecFile := filepath.Join(dir, strings.ToLower(pkg.Name)+".nuspec")
+	nuspec, err := resolver.ReadNuspec(nuspecFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Dependency: pkg.Name, Version: pkg.Version, LicenseFilePath: nuspecFile}
+	lcs := nuspec.Metadata.License
+	switch value := strings.TrimSpace(lcs.Value); {
+	case value != "" && lcs.Type == "expression":
+		result.LicenseSpdxID = value
+		return result, nil
+	case value != "" && lcs.Type == "file":
+		licenseFile := filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(value, `\`, "/")))
+		return resolver.IdentifyLicenseFile(config, result, licenseFile)
+	}
+
+	if licenseURL := strings.TrimSpace(nuspec.Metadata.LicenseURL); licenseURL != "" {
+		// the packages with license expressions have the license URLs like https://licenses.nuget.org/MIT
+		if u, err := url.Parse(licenseURL); err == nil && strings.EqualFold(u.Host, nugetLicenseHost) && len(u.Path) > 1 {
+			result.LicenseSpdxID = u.Path[1:]
+			return result, nil
+		}
+		if l, err := license.Identify(licenseURL, config.Threshold); err == nil {
+			result.LicenseSpdxID = l
+			result.LicenseContent = licenseURL
+			return result, nil
+		}
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if !file.IsDir() && possibleLicenseFileName.MatchString(file.Name()) {
+			return resolver.IdentifyLicenseFile(config, result, filepath.Join(dir, file.Name()))
+		}
+	}
+	return nil, fmt.Errorf("cannot find the license in %v or the license file", nuspecFile)
+}
+
+// IdentifyLicenseFile identifies the license of the license file in the package.
+func (resolver *NuGetResolver) IdentifyLicenseFile(config *ConfigDeps, result *Result, licenseFile string) (*Result, error) {
+	content, err := os.ReadFile(licenseFile)
+	if err != nil {
+		return nil, err
+	}
+	identifier, err := license.Identify(string(content), config.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", licenseFile, err)
+	}
+	result.LicenseFilePath = licenseFile
+	result.LicenseContent = string(content)
+	result.LicenseSpdxID = identifier
+	return result, nil
+}
+
+// ReadNuspec reads the .nuspec file.
+func (resolver *NuGetResolver) ReadNuspec(nuspecFile string) (*Nuspec, error) {
+	file, err := os.Open(nuspecFile)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	dec := xml.NewDecoder(file)
+	dec.CharsetReader = charset.NewReaderLabel
+
+	nuspec := new(Nuspec)
+	if err := dec.Decode(nuspec); err != nil {
+		return nil, fmt.Errorf("%v: %w", nuspecFile, err)
+	}
+	return nuspec, nil
+}
+
+// NuGetPackagesFolder returns the global packages folder of NuGet.
+func NuGetPackagesFolder() string {
+	if folder := os.Getenv("NUGET_PACKAGES"); folder != "" {
+		return folder
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".nuget", "packages")
+	}
+	return filepath.Join(home, ".nuget", "packages")
+}
+
+// NuGetPackageDir returns the directory of the package in the packages folders, which is laid out as
+// <lower-case id>/<lower-case version>, or an empty string if it's not found.
+func NuGetPackageDir(folders []string, pkg *NuGetPackage) string {
+	for _, folder := range folders {
+		dir := filepath.Join(folder, strings.ToLower(pkg.Name), strings.ToLower(pkg.Version))
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// parseNuGetPackagesLock parses packages.lock.json, which locks the packages for each target framework.
+func parseNuGetPackagesLock(content []byte) ([]*NuGetPackage, error) {
+	var lock struct {
+		Dependencies map[string]map[string]struct {
+			Type         string            `json:"type"`
+			Resolved     string            `json:"resolved"`
+			Dependencies map[string]string `json:"dependencies"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	pkgs := make(map[string]*NuGetPackage)
+	for _, target := range lock.Dependencies {
+		for name, dep := range target {
+			if dep.Type == "Project" || dep.Resolved == "" {
+				continue
+			}
+			pkg := addNuGetPackage(pkgs, name, dep.Resolved, dep.Dependencies)
+			pkg.Direct = pkg.Direct || dep.Type == "Direct"
+		}
+	}
+	return sortNuGetPackages(pkgs), nil
+}
+
+// parseNuGetAssets parses obj/project.assets.json, which records the restored packages for each target framework,
+// and the packages folders where they're restored.
+func parseNuGetAssets(content []byte) ([]*NuGetPackage, []string, error) {
+	var assets struct {
+		Targets map[string]map[string]struct {
+			Type         string            `json:"type"`
+			Dependencies map[string]string `json:"dependencies"`
+		} `json:"targets"`
+		PackageFolders map[string]any `json:"packageFolders"`
+		Project        struct {
+			Frameworks map[string]struct {
+				Dependencies map[string]struct {
+					Target string `json:"target"`
+				} `json:"dependencies"`
+			} `json:"frameworks"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(content, &assets); err != nil {
+		return nil, nil, err
+	}
+
+	direct := make(map[string]bool)
+	for _, framework := range assets.Project.Frameworks {
+		for name, dep := range framework.Dependencies {
+			if dep.Target == "" || dep.Target == "Package" {
+				direct[strings.ToLower(name)] = true
+			}
+		}
+	}
+
+	pkgs := make(map[string]*NuGetPackage)
+	for _, target := range assets.Targets {
+		for library, dep := range target {
+			name, version, ok := strings.Cut(library, "/")
+			if !ok || dep.Type != "package" {
+				continue
+			}
+			pkg := addNuGetPackage(pkgs, name, version, dep.Dependencies)
+			pkg.Direct = direct[strings.ToLower(name)]
+		}
+	}
+
+	folders := make([]string, 0, len(assets.PackageFolders))
+	for folder := range assets.PackageFolders {
+		folders = append(folders, folder)
+	}
+	sort.Strings(folders)
+	return sortNuGetPackages(pkgs), folders, nil
+}
+
+// addNuGetPackage adds the package to the packages keyed by the name and the version, the same package locked for
+// multiple target frameworks is added once, with the dependencies of all of them.
+func addNuGetPackage(pkgs map[string]*NuGetPackage, name, version string, dependencies map[string]string) *NuGetPackage {
+	key := strings.ToLower(name + "@" + version)
+	pkg, ok := pkgs[key]
+	if !ok {
+		pkg = &NuGetPackage{Name: name, Version: version}
+		pkgs[key] = pkg
+	}
+	for dep := range dependencies {
+		pkg.Dependencies = appendUnique(pkg.Dependencies, dep)
+	}
+	sort.Strings(pkg.Dependencies)
+	return pkg
+}
+
+func sortNuGetPackages(pkgs map[string]*NuGetPackage) []*NuGetPackage {
+	keys := make([]string, 0, len(pkgs))
+	for key := range pkgs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sorted := make([]*NuGetPackage, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, pkgs[key])
+	}
+	return sorted
+}
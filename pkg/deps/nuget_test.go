@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+func nuspec(id, version, metadata string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd">
+  <metadata>
+    <id>%v</id>
+    <version>%v</version>
+    %v
+  </metadata>
+</package>`, id, version, metadata)
+}
+
+// nugetPackages is the global packages folder with the packages restored.
+var nugetPackages = map[string]string{
+	"newtonsoft.json/13.0.3/newtonsoft.json.nuspec": nuspec("Newtonsoft.Json", "13.0.3",
+		`<license type="expression">MIT</license>
+    <licenseUrl>https://licenses.nuget.org/MIT</licenseUrl>`),
+	"serilog/3.1.1/serilog.nuspec": nuspec("Serilog", "3.1.1",
+		`<licenseUrl>https://licenses.nuget.org/Apache-2.0</licenseUrl>`),
+	"legacy.lib/1.0.0/legacy.lib.nuspec": nuspec("Legacy.Lib", "1.0.0",
+		`<licenseUrl>http://www.apache.org/licenses/LICENSE-2.0.txt</licenseUrl>`),
+	"embedded.lib/2.0.0/embedded.lib.nuspec": nuspec("Embedded.Lib", "2.0.0",
+		`<license type="file">docs\LICENSE.txt</license>
+    <licenseUrl>https://aka.ms/deprecateLicenseUrl</licenseUrl>`),
+	"embedded.lib/2.0.0/docs/LICENSE.txt":  mitLicense,
+	"unknown.lib/1.0.0/unknown.lib.nuspec": nuspec("Unknown.Lib", "1.0.0", ""),
+}
+
+func TestCanResolveNuGet(t *testing.T) {
+	resolver := new(deps.NuGetResolver)
+	for _, test := range []struct {
+		fileName string
+		exp      bool
+	}{
+		{"packages.lock.json", true},
+		{"obj/project.assets.json", true},
+		{"app.csproj", true},
+		{"package-lock.json", false},
+	} {
+		if b := resolver.CanResolve(test.fileName); b != test.exp {
+			t.Errorf("NuGetResolver.CanResolve(\"%v\") = %v, want %v", test.fileName, b, test.exp)
+		}
+	}
+}
+
+func resolveNuGet(t *testing.T, file string) string {
+	t.Helper()
+	report := deps.Report{}
+	config := &deps.ConfigDeps{Threshold: 75, Files: []string{file}}
+	if err := deps.Resolve(config, &report); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range report.Resolved {
+		got = append(got, fmt.Sprintf("%v@%v %v %v %v", r.Dependency, r.Version, r.LicenseSpdxID, r.Direct, r.PURL))
+	}
+	for _, r := range report.Skipped {
+		got = append(got, fmt.Sprintf("%v@%v skipped", r.Dependency, r.Version))
+	}
+	return strings.Join(got, "\n")
+}
+
+func TestResolveNuGetPackagesLock(t *testing.T) {
+	dir := t.TempDir()
+	packages := t.TempDir()
+	t.Setenv("NUGET_PACKAGES", packages)
+	writePythonFiles(t, packages, nugetPackages)
+	writePythonFiles(t, dir, map[string]string{"packages.lock.json": `{
+  "version": 1,
+  "dependencies": {
+    "net8.0": {
+      "Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.3, )", "resolved": "13.0.3", "contentHash": "0"},
+      "Serilog": {"type": "Direct", "requested": "[3.1.1, )", "resolved": "3.1.1", "contentHash": "0",
+        "dependencies": {"Legacy.Lib": "1.0.0"}},
+      "Legacy.Lib": {"type": "Transitive", "resolved": "1.0.0", "contentHash": "0"},
+      "Embedded.Lib": {"type": "Transitive", "resolved": "2.0.0", "contentHash": "0"},
+      "Missing.Lib": {"type": "Transitive", "resolved": "9.9.9", "contentHash": "0"},
+      "Unknown.Lib": {"type": "Transitive", "resolved": "1.0.0", "contentHash": "0"},
+      "mylib": {"type": "Project"}
+    },
+    "net8.0/linux-x64": {
+      "Serilog": {"type": "Direct", "requested": "[3.1.1, )", "resolved": "3.1.1", "contentHash": "0"}
+    }
+  }
+}`})
+
+	want := `Embedded.Lib@2.0.0 MIT false pkg:nuget/Embedded.Lib@2.0.0
+Legacy.Lib@1.0.0 Apache-2.0 false pkg:nuget/Legacy.Lib@1.0.0
+Newtonsoft.Json@13.0.3 MIT true pkg:nuget/Newtonsoft.Json@13.0.3
+Serilog@3.1.1 Apache-2.0 true pkg:nuget/Serilog@3.1.1
+Missing.Lib@9.9.9 skipped
+Unknown.Lib@1.0.0 skipped`
+	if got := resolveNuGet(t, filepath.Join(dir, "packages.lock.json")); got != want {
+		t.Errorf("resolved\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestResolveNuGetProjectAssets(t *testing.T) {
+	dir := t.TempDir()
+	packages := t.TempDir()
+	t.Setenv("NUGET_PACKAGES", t.TempDir())
+	writePythonFiles(t, packages, nugetPackages)
+	writePythonFiles(t, dir, map[string]string{"obj/project.assets.json": fmt.Sprintf(`{
+  "version": 3,
+  "targets": {
+    "net8.0": {
+      "Serilog/3.1.1": {"type": "package", "dependencies": {"Legacy.Lib": "1.0.0"}},
+      "Legacy.Lib/1.0.0": {"type": "package"},
+      "MyLib/1.0.0": {"type": "project"}
+    }
+  },
+  "packageFolders": {%q: {}},
+  "project": {
+    "frameworks": {
+      "net8.0": {
+        "dependencies": {"Serilog": {"target": "Package", "version": "[3.1.1, )"}}
+      }
+    }
+  }
+}`, packages)})
+
+	want := `Legacy.Lib@1.0.0 Apache-2.0 false pkg:nuget/Legacy.Lib@1.0.0
+Serilog@3.1.1 Apache-2.0 true pkg:nuget/Serilog@3.1.1`
+	if got := resolveNuGet(t, filepath.Join(dir, "obj", "project.assets.json")); got != want {
+		t.Errorf("resolved\n%v\nwant\n%v", got, want)
+	}
+	if got := resolveNuGet(t, filepath.Join(dir, "app.csproj")); got != want {
+		t.Errorf("resolved from the project file\n%v\nwant\n%v", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// purlScheme is the scheme of the package URLs.
+const purlScheme = "pkg:"
+
+// The ecosystems of the dependencies, named after the package URL types.
+const (
+	EcosystemGolang   = "golang"
+	EcosystemNpm      = "npm"
+	EcosystemMaven    = "maven"
+	EcosystemCargo    = "cargo"
+	EcosystemGem      = "gem"
+	EcosystemPyPI     = "pypi"
+	EcosystemComposer = "composer"
+	EcosystemNuGet    = "nuget"
+)
+
+// ecosystemOf returns the ecosystem of the dependencies resolved by the resolver.
+func ecosystemOf(resolver Resolver) string {
+	switch resolver.(type) {
+	case *GoModResolver:
+		return EcosystemGolang
+	case *NpmResolver:
+		return EcosystemNpm
+	case *MavenPomResolver, *GradleResolver, *JarResolver:
+		return EcosystemMaven
+	case *CargoTomlResolver:
+		return EcosystemCargo
+	case *GemfileLockResolver:
+		return EcosystemGem
+	case *PythonResolver:
+		return EcosystemPyPI
+	case *ComposerResolver:
+		return EcosystemComposer
+	case *NuGetResolver:
+		return EcosystemNuGet
+	}
+	return ""
+}
+
+// PackageURL returns the package URL (https://github.com/package-url/purl-spec) of the dependency,
+// or an empty string if its ecosystem is unknown or its name doesn't identify a package, such as a jar file name.
+func PackageURL(result *Result) string {
+	if result.PURL != "" {
+		return result.PURL
+	}
+	var path []string
+	switch result.Ecosystem {
+	case EcosystemGolang, EcosystemNpm, EcosystemComposer:
+		path = strings.Split(result.Dependency, "/")
+	case EcosystemMaven:
+		group, artifact, ok := strings.Cut(result.Dependency, ":")
+		if !ok {
+			return ""
+		}
+		path = []string{group, artifact}
+	case EcosystemCargo, EcosystemGem, EcosystemNuGet:
+		path = []string{result.Dependency}
+	case EcosystemPyPI:
+		path = []string{normalizePythonName(result.Dependency)}
+	default:
+		return ""
+	}
+
+	for i, segment := range path {
+		if segment == "" {
+			return ""
+		}
+		path[i] = escapePURL(segment)
+	}
+	purl := fmt.Sprintf("%v%v/%v", purlScheme, result.Ecosystem, strings.Join(path, "/"))
+	if result.Version != "" && result.Version != Unknown {
+		purl += "@" + escapePURL(result.Version)
+	}
+	return purl
+}
+
+// escapePURL percent-encodes the characters that are not allowed in the package URL components.
+func escapePURL(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte(".-_~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// unescapePURL decodes the percent-encoded characters in the package URL.
+func unescapePURL(purl string) string {
+	if unescaped, err := url.PathUnescape(purl); err == nil {
+		return unescaped
+	}
+	return purl
+}
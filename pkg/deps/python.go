@@ -0,0 +1,669 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/internal/toml"
+	"github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// PythonResolver resolves Python dependencies from requirements.txt, poetry.lock, uv.lock and Pipfile.lock.
+// The licenses are read from the metadata of the packages installed in the virtualenv (site-packages/*.dist-info),
+// so it works offline, the virtualenv is dependency.python_venv in the config, or .venv / venv next to the lock file,
+// or the activated one (VIRTUAL_ENV).
+// - requirements.txt: all the requirements are included, the ones annotated by "# via" other packages are transitive.
+// - poetry.lock / uv.lock: only the packages reachable from the runtime dependencies of the project are included.
+// - Pipfile.lock: the default packages are included, the develop ones are not.
+type PythonResolver struct {
+	Resolver
+}
+
+const (
+	poetryLock  = "poetry.lock"
+	uvLock      = "uv.lock"
+	pipfileLock = "Pipfile.lock"
+)
+
+var requirementsFile = regexp.MustCompile(`^requirements.*\.txt$`)
+
+// PythonPackage is a package in the Python lock files.
+type PythonPackage struct {
+	Name         string
+	Version      string
+	Direct       bool
+	Dependencies []string
+}
+
+func (resolver *PythonResolver) CanResolve(file string) bool {
+	base := filepath.Base(file)
+	return base == poetryLock || base == uvLock || base == pipfileLock || requirementsFile.MatchString(base)
+}
+
+// Resolve resolves licenses of all dependencies declared in the Python lock file.
+func (resolver *PythonResolver) Resolve(lockFile string, config *ConfigDeps, report *Report) error {
+	pkgs, err := resolver.LoadPackages(lockFile)
+	if err != nil {
+		return err
+	}
+
+	sitePackages, err := resolver.FindSitePackages(config.PythonVenv, filepath.Dir(lockFile))
+	if err != nil {
+		return err
+	}
+	if len(sitePackages) == 0 {
+		logger.Log.Warnf("Cannot find the site-packages of the virtualenv for %v, set dependency.python_venv in the config", lockFile)
+	}
+	distInfos := resolver.DistInfos(sitePackages)
+
+	for _, pkg := range pkgs {
+		for _, dep := range pkg.Dependencies {
+			report.Depend(pkg.Name, dep)
+		}
+
+		if exclude, _ := config.IsExcluded(EcosystemPyPI, pkg.Name, pkg.Version); exclude {
+			continue
+		}
+		if l, ok := config.GetUserConfiguredLicense(EcosystemPyPI, pkg.Name, pkg.Version); ok {
+			report.Resolve(&Result{Dependency: pkg.Name, LicenseSpdxID: l, Version: pkg.Version, Direct: pkg.Direct})
+			continue
+		}
+
+		distInfo, ok := distInfos[normalizePythonName(pkg.Name)]
+		if !ok {
+			logger.Log.Warnf("Failed to resolve the license of <%s@%s>: the package is not installed\n", pkg.Name, pkg.Version)
+			report.Skip(&Result{
+				Dependency:    pkg.Name,
+				LicenseSpdxID: Unknown,
+				Version:       pkg.Version,
+				Direct:        pkg.Direct,
+				ResolveErrors: []error{fmt.Errorf("the package is not installed in the virtualenv")},
+			})
+			continue
+		}
+		result, err := resolver.ResolvePackageLicense(config, pkg, distInfo)
+		if err != nil {
+			logger.Log.Warnf("Failed to resolve the license of <%s@%s>: %v\n", pkg.Name, pkg.Version, err)
+			report.Skip(&Result{
+				Dependency:    pkg.Name,
+				LicenseSpdxID: Unknown,
+				Version:       pkg.Version,
+				Direct:        pkg.Direct,
+				ResolveErrors: []error{err},
+			})
+			continue
+		}
+		report.Resolve(result)
+	}
+
+	return nil
+}
+
+// LoadPackages loads the packages from the lock file, and marks the direct ones.
+func (resolver *PythonResolver) LoadPackages(lockFile string) ([]*PythonPackage, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(lockFile)
+	switch base := filepath.Base(lockFile); base {
+	case poetryLock:
+		pkgs, err := parsePoetryLock(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", lockFile, err)
+		}
+		roots, err := pyprojectDependencies(filepath.Join(dir, "pyproject.toml"))
+		if err != nil {
+			return nil, err
+		}
+		return selectPythonPackages(pkgs, roots), nil
+	case uvLock:
+		pkgs, roots, err := parseUvLock(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", lockFile, err)
+		}
+		return selectPythonPackages(pkgs, roots), nil
+	case pipfileLock:
+		pkgs, err := parsePipfileLock(content)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", lockFile, err)
+		}
+		roots, err := pipfileDependencies(filepath.Join(dir, "Pipfile"))
+		if err != nil {
+			return nil, err
+		}
+		direct := make(map[string]bool)
+		for _, root := range roots {
+			direct[normalizePythonName(root)] = true
+		}
+		for _, pkg := range pkgs {
+			pkg.Direct = direct[normalizePythonName(pkg.Name)]
+		}
+		return pkgs, nil
+	default:
+		return parseRequirements(string(content)), nil
+	}
+}
+
+// FindSitePackages returns the site-packages directories of the virtualenv, which is the given one, or .venv / venv in
+// the directory, or the activated one. It's an error if the given virtualenv has no site-packages.
+func (resolver *PythonResolver) FindSitePackages(venv, dir string) ([]string, error) {
+	candidates := []string{venv}
+	if venv == "" {
+		candidates = []string{filepath.Join(dir, ".venv"), filepath.Join(dir, "venv"), os.Getenv("VIRTUAL_ENV")}
+	}
+
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		var sitePackages []string
+		for _, pattern := range []string{"lib/python*/site-packages", "lib64/python*/site-packages", "Lib/site-packages"} {
+			matches, _ := filepath.Glob(filepath.Join(candidate, pattern))
+			sitePackages = append(sitePackages, matches...)
+		}
+		// the site-packages directory itself
+		if matches, _ := filepath.Glob(filepath.Join(candidate, "*.dist-info")); len(matches) > 0 {
+			sitePackages = append(sitePackages, candidate)
+		}
+		if len(sitePackages) > 0 {
+			return sitePackages, nil
+		}
+	}
+
+	if venv != "" {
+		return nil, fmt.Errorf("cannot find site-packages in the virtualenv %v", venv)
+	}
+	return nil, nil
+}
+
+// DistInfos returns the *.dist-info directories in the site-packages, keyed by the normalized package names.
+func (resolver *PythonResolver) DistInfos(sitePackages []string) map[string]string {
+	distInfos := make(map[string]string)
+	for _, dir := range sitePackages {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.dist-info"))
+		for _, match := range matches {
+			// the "-" in the name is escaped as "_", so the first "-" separates the name and the version
+			name, _, _ := strings.Cut(strings.TrimSuffix(filepath.Base(match), ".dist-info"), "-")
+			distInfos[normalizePythonName(name)] = match
+		}
+	}
+	return distInfos
+}
+
+// ResolvePackageLicense resolves the license of the package from its METADATA in the dist-info directory,
+// the License-Expression field goes first, then the License field and the license classifiers, and the license files
+// are identified at last.
+func (resolver *PythonResolver) ResolvePackageLicense(config *ConfigDeps, pkg *PythonPackage, distInfo string) (*Result, error) {
+	metadata, err := parsePythonMetadata(filepath.Join(distInfo, "METADATA"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Dependency: pkg.Name, Version: pkg.Version, Direct: pkg.Direct}
+	if result.Version == "" {
+		result.Version = metadata.Version
+	}
+	result.LicenseFilePath, result.LicenseContent = pythonLicenseFile(distInfo, metadata.LicenseFiles)
+
+	if expression, err := license.ParseExpression(metadata.LicenseExpression); err == nil {
+		result.LicenseSpdxID = expression
+		return result, nil
+	}
+	if expression, err := license.ParseExpression(metadata.License); err == nil {
+		result.LicenseSpdxID = expression
+		return result, nil
+	}
+	if ids := classifierLicenses(metadata.Classifiers); len(ids) > 0 {
+		result.LicenseSpdxID = strings.Join(ids, " OR ")
+		return result, nil
+	}
+	if result.LicenseContent != "" {
+		identifier, err := license.Identify(result.LicenseContent, config.Threshold)
+		if err == nil {
+			result.LicenseSpdxID = identifier
+			return result, nil
+		}
+	}
+	// the License field may be the full license text, or a license name
+	if l := strings.TrimSpace(metadata.License); l != "" && !strings.EqualFold(l, Unknown) {
+		if identifier, err := license.Identify(l, config.Threshold); err == nil {
+			result.LicenseSpdxID = identifier
+		} else if !strings.Contains(l, "\n") {
+			result.LicenseSpdxID = l
+		}
+		if result.LicenseSpdxID != "" {
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot find the license in the metadata or the license files")
+}
+
+// pythonLicenseFile returns the path and the content of the license file of the package, the License-File fields
+// are located in the dist-info directory or its licenses directory (metadata 2.4), the other license files are the
+// fallback.
+func pythonLicenseFile(distInfo string, licenseFiles []string) (path, content string) {
+	var candidates []string
+	for _, file := range licenseFiles {
+		candidates = append(candidates, filepath.Join(distInfo, "licenses", file), filepath.Join(distInfo, file))
+	}
+	for _, dir := range []string{distInfo, filepath.Join(distInfo, "licenses")} {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if !entry.IsDir() && possibleLicenseFileName.MatchString(entry.Name()) {
+				candidates = append(candidates, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if bs, err := os.ReadFile(candidate); err == nil {
+			return candidate, string(bs)
+		}
+	}
+	return "", ""
+}
+
+// -------- Parsing METADATA --------
+
+// PythonMetadata is the core metadata of the Python package, only the license related fields are parsed.
+type PythonMetadata struct {
+	Name              string
+	Version           string
+	License           string
+	LicenseExpression string
+	LicenseFiles      []string
+	Classifiers       []string
+}
+
+// parsePythonMetadata parses the METADATA file, the headers are in the email format, the indented lines continue the
+// previous header, and the body after the first empty line is ignored.
+func parsePythonMetadata(file string) (*PythonMetadata, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &PythonMetadata{}
+	var key, value string
+	flush := func() {
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(key) {
+		case "name":
+			metadata.Name = value
+		case "version":
+			metadata.Version = value
+		case "license":
+			metadata.License = value
+		case "license-expression":
+			metadata.LicenseExpression = value
+		case "license-file":
+			metadata.LicenseFiles = append(metadata.LicenseFiles, value)
+		case "classifier":
+			metadata.Classifiers = append(metadata.Classifiers, value)
+		}
+		key, value = "", ""
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			// older tools prefix the continuation lines with 8 spaces and "|"
+			value += "\n" + strings.TrimPrefix(strings.TrimSpace(line), "|")
+			continue
+		}
+		flush()
+		k, v, ok := strings.Cut(line, ":")
+		if ok {
+			key, value = k, v
+		}
+	}
+	flush()
+
+	return metadata, scanner.Err()
+}
+
+// pythonClassifierLicenses maps the license classifiers to the SPDX IDs, the ambiguous ones such as
+// "License :: OSI Approved :: BSD License" are not mapped.
+var pythonClassifierLicenses = map[string]string{
+	"Academic Free License (AFL)":                                "AFL-3.0",
+	"Apache Software License":                                    "Apache-2.0",
+	"Boost Software License 1.0 (BSL-1.0)":                       "BSL-1.0",
+	"Eclipse Public License 1.0 (EPL-1.0)":                       "EPL-1.0",
+	"Eclipse Public License 2.0 (EPL-2.0)":                       "EPL-2.0",
+	"GNU Affero General Public License v3":                       "AGPL-3.0-only",
+	"GNU Affero General Public License v3 or later (AGPLv3+)":    "AGPL-3.0-or-later",
+	"GNU General Public License v2 (GPLv2)":                      "GPL-2.0-only",
+	"GNU General Public License v2 or later (GPLv2+)":            "GPL-2.0-or-later",
+	"GNU General Public License v3 (GPLv3)":                      "GPL-3.0-only",
+	"GNU General Public License v3 or later (GPLv3+)":            "GPL-3.0-or-later",
+	"GNU Lesser General Public License v2 (LGPLv2)":              "LGPL-2.0-only",
+	"GNU Lesser General Public License v2 or later (LGPLv2+)":    "LGPL-2.0-or-later",
+	"GNU Lesser General Public License v3 (LGPLv3)":              "LGPL-3.0-only",
+	"GNU Lesser General Public License v3 or later (LGPLv3+)":    "LGPL-3.0-or-later",
+	"Historical Permission Notice and Disclaimer (HPND)":         "HPND",
+	"ISC License (ISCL)":                                         "ISC",
+	"MIT License":                                                "MIT",
+	"MIT No Attribution License (MIT-0)":                         "MIT-0",
+	"Mozilla Public License 1.1 (MPL 1.1)":                       "MPL-1.1",
+	"Mozilla Public License 2.0 (MPL 2.0)":                       "MPL-2.0",
+	"Python Software Foundation License":                         "PSF-2.0",
+	"The Unlicense (Unlicense)":                                  "Unlicense",
+	"Universal Permissive License (UPL)":                         "UPL-1.0",
+	"Zope Public License":                                        "ZPL-2.1",
+	"zlib/libpng License":                                        "Zlib",
+	"GNU Library or Lesser General Public License (LGPL)":        "LGPL-2.0-or-later",
+	"European Union Public Licence 1.2 (EUPL 1.2)":               "EUPL-1.2",
+	"Common Development and Distribution License 1.0 (CDDL-1.0)": "CDDL-1.0",
+}
+
+// classifierLicenses returns the SPDX IDs of the license classifiers, such as
+// "License :: OSI Approved :: MIT License".
+func classifierLicenses(classifiers []string) []string {
+	var ids []string
+	for _, classifier := range classifiers {
+		if !strings.HasPrefix(classifier, "License ::") {
+			continue
+		}
+		parts := strings.Split(classifier, "::")
+		if id, ok := pythonClassifierLicenses[strings.TrimSpace(parts[len(parts)-1])]; ok && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+var nonNormalizedPythonName = regexp.MustCompile(`[-_.]+`)
+
+// normalizePythonName normalizes the package name as PEP 503, such as "Foo.Bar_baz" to "foo-bar-baz".
+func normalizePythonName(name string) string {
+	return strings.ToLower(nonNormalizedPythonName.ReplaceAllString(name, "-"))
+}
+
+// -------- Parsing lock files --------
+
+// requirementPattern matches the name and the pinned version of a requirement, such as "requests[socks]==2.31.0".
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(?:===?\s*([^\s;,\\]+))?`)
+
+// unnamedRequirement matches the requirements of URLs, including the VCS ones such as "git+https://...#egg=foo", and
+// of local paths, which don't start with the package names.
+var unnamedRequirement = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9+.-]*:|[./~\\])`)
+
+// parseRequirements parses the requirements.txt file, the "# via" annotations generated by pip-compile tell the
+// parents of the requirements, the ones via the requirement files (-r) or the project are direct.
+func parseRequirements(content string) []*PythonPackage {
+	var pkgs []*PythonPackage
+	parents := make(map[*PythonPackage][]string)
+	var current *PythonPackage
+	inVia := false
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "#") {
+			comment := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			if rest, ok := strings.CutPrefix(comment, "via"); ok && (rest == "" || rest[0] == ' ') {
+				inVia, comment = true, strings.TrimSpace(rest)
+			}
+			if inVia && current != nil && comment != "" {
+				parents[current] = append(parents[current], comment)
+			}
+			continue
+		}
+		inVia = false
+
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = strings.TrimSuffix(line, "\\") + " " + strings.TrimSpace(lines[i])
+		}
+		line = strings.TrimSpace(strings.Split(line, " #")[0])
+		// the options, such as "-r requirements.in" and "-e .", are skipped, so are the unnamed requirements
+		if line == "" || strings.HasPrefix(line, "-") || unnamedRequirement.MatchString(line) {
+			continue
+		}
+		if m := requirementPattern.FindStringSubmatch(line); m != nil {
+			current = &PythonPackage{Name: m[1], Version: m[2]}
+			pkgs = append(pkgs, current)
+		}
+	}
+
+	byName := make(map[string]*PythonPackage)
+	for _, pkg := range pkgs {
+		byName[normalizePythonName(pkg.Name)] = pkg
+	}
+	for _, pkg := range pkgs {
+		pkg.Direct = len(parents[pkg]) == 0
+		for _, parent := range parents[pkg] {
+			if p, ok := byName[normalizePythonName(parent)]; ok {
+				p.Dependencies = append(p.Dependencies, pkg.Name)
+			} else {
+				// via the requirement files (-r requirements.in) or the project (foo (pyproject.toml))
+				pkg.Direct = true
+			}
+		}
+	}
+	return pkgs
+}
+
+// parsePoetryLock parses the [[package]] tables in the poetry.lock file, the dev packages of the legacy lock files are
+// ignored.
+func parsePoetryLock(content string) ([]*PythonPackage, error) {
+	tables, err := toml.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*PythonPackage
+	var current *PythonPackage
+	for _, table := range tables {
+		switch table.Name {
+		case "package":
+			current = nil
+			if table.String("category") == "dev" {
+				continue
+			}
+			current = &PythonPackage{Name: table.String("name"), Version: table.String("version")}
+			pkgs = append(pkgs, current)
+		case "package.dependencies":
+			if current != nil {
+				current.Dependencies = append(current.Dependencies, table.Keys...)
+			}
+		}
+	}
+	return pkgs, nil
+}
+
+var uvDependencyName = regexp.MustCompile(`name\s*=\s*"([^"]+)"`)
+
+// parseUvLock parses the [[package]] tables in the uv.lock file, the packages whose sources are editable or virtual
+// are the projects in the workspace, and their runtime dependencies are the roots.
+func parseUvLock(content string) (pkgs []*PythonPackage, roots []string, err error) {
+	tables, err := toml.Parse(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, table := range tables {
+		if table.Name != "package" {
+			continue
+		}
+		var deps []string
+		for _, m := range uvDependencyName.FindAllStringSubmatch(table.Values["dependencies"], -1) {
+			deps = append(deps, m[1])
+		}
+		source := table.Values["source"]
+		if strings.Contains(source, "editable") || strings.Contains(source, "virtual") {
+			roots = append(roots, deps...)
+			continue
+		}
+		pkgs = append(pkgs, &PythonPackage{
+			Name:         table.String("name"),
+			Version:      table.String("version"),
+			Dependencies: deps,
+		})
+	}
+	return pkgs, roots, nil
+}
+
+// parsePipfileLock parses the default packages in the Pipfile.lock file.
+func parsePipfileLock(content []byte) ([]*PythonPackage, error) {
+	var lock struct {
+		Default map[string]struct {
+			Version string `json:"version"`
+		} `json:"default"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	pkgs := make([]*PythonPackage, 0, len(lock.Default))
+	for name, pkg := range lock.Default {
+		pkgs = append(pkgs, &PythonPackage{Name: name, Version: strings.TrimPrefix(pkg.Version, "==")})
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Name < pkgs[j].Name })
+	return pkgs, nil
+}
+
+// pyprojectDependencies returns the runtime dependencies of the project in the pyproject.toml file, both the
+// [tool.poetry.dependencies] table and the dependencies of the [project] table are supported.
+func pyprojectDependencies(file string) ([]string, error) {
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tables, err := toml.Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", file, err)
+	}
+
+	var deps []string
+	for _, table := range tables {
+		switch table.Name {
+		case "tool.poetry.dependencies":
+			for _, key := range table.Keys {
+				if key != "python" {
+					deps = append(deps, key)
+				}
+			}
+		case "project":
+			if _, ok := table.Values["dependencies"]; !ok {
+				continue
+			}
+			requirements, err := table.Strings("dependencies")
+			if err != nil {
+				return nil, fmt.Errorf("%v:%d: %w", file, table.Lines["dependencies"], err)
+			}
+			for _, requirement := range requirements {
+				if m := requirementPattern.FindStringSubmatch(requirement); m != nil {
+					deps = append(deps, m[1])
+				}
+			}
+		}
+	}
+	return deps, nil
+}
+
+// pipfileDependencies returns the keys of the [packages] table in the Pipfile.
+func pipfileDependencies(file string) ([]string, error) {
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tables, err := toml.Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", file, err)
+	}
+	for _, table := range tables {
+		if table.Name == "packages" {
+			return table.Keys, nil
+		}
+	}
+	return nil, nil
+}
+
+// selectPythonPackages returns the packages reachable from the roots and marks the roots as direct, all the packages
+// are returned if the roots are unknown, and the ones not depended by the others are direct.
+func selectPythonPackages(pkgs []*PythonPackage, roots []string) []*PythonPackage {
+	byName := make(map[string]*PythonPackage)
+	for _, pkg := range pkgs {
+		byName[normalizePythonName(pkg.Name)] = pkg
+	}
+
+	if len(roots) == 0 {
+		depended := make(map[string]bool)
+		for _, pkg := range pkgs {
+			for _, dep := range pkg.Dependencies {
+				depended[normalizePythonName(dep)] = true
+			}
+		}
+		for _, pkg := range pkgs {
+			pkg.Direct = !depended[normalizePythonName(pkg.Name)]
+		}
+		return pkgs
+	}
+
+	included := make(map[*PythonPackage]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		pkg, ok := byName[normalizePythonName(name)]
+		if !ok || included[pkg] {
+			return
+		}
+		included[pkg] = true
+		for _, dep := range pkg.Dependencies {
+			visit(dep)
+		}
+	}
+	for _, root := range roots {
+		if pkg, ok := byName[normalizePythonName(root)]; ok {
+			pkg.Direct = true
+		}
+		visit(root)
+	}
+
+	var selected []*PythonPackage
+	for _, pkg := range pkgs {
+		if included[pkg] {
+			selected = append(selected, pkg)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,281 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+const mitLicense = `MIT License
+
+Copyright (c) 2024 Foo
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+`
+
+func writePythonFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// pythonVenv is a virtualenv with the packages installed.
+var pythonVenv = map[string]string{
+	".venv/lib/python3.12/site-packages/requests-2.31.0.dist-info/METADATA": "Metadata-Version: 2.4\n" +
+		"Name: requests\nVersion: 2.31.0\nLicense-Expression: Apache-2.0\nLicense-File: LICENSE\n\nThe body.\n",
+	".venv/lib/python3.12/site-packages/requests-2.31.0.dist-info/licenses/LICENSE": "Apache License\n",
+	".venv/lib/python3.12/site-packages/certifi-2024.2.2.dist-info/METADATA": "Metadata-Version: 2.1\n" +
+		"Name: certifi\nVersion: 2024.2.2\nLicense: MPL 2.0\n" +
+		"Classifier: Development Status :: 5 - Production/Stable\n" +
+		"Classifier: License :: OSI Approved :: Mozilla Public License 2.0 (MPL 2.0)\n",
+	".venv/lib/python3.12/site-packages/charset_normalizer-3.3.2.dist-info/METADATA": "Metadata-Version: 2.1\n" +
+		"Name: charset-normalizer\nVersion: 3.3.2\nLicense: UNKNOWN\n",
+	".venv/lib/python3.12/site-packages/charset_normalizer-3.3.2.dist-info/LICENSE": mitLicense,
+	".venv/lib/python3.12/site-packages/pytest-8.0.0.dist-info/METADATA": "Metadata-Version: 2.1\n" +
+		"Name: pytest\nVersion: 8.0.0\nLicense: MIT\n",
+}
+
+func TestCanResolvePython(t *testing.T) {
+	resolver := new(deps.PythonResolver)
+	for _, file := range []string{"requirements.txt", "requirements-prod.txt", "poetry.lock", "uv.lock", "Pipfile.lock"} {
+		if !resolver.CanResolve(file) {
+			t.Errorf("PythonResolver should resolve %v", file)
+		}
+	}
+	if resolver.CanResolve("Pipfile") || resolver.CanResolve("pyproject.toml") {
+		t.Error("PythonResolver shouldn't resolve Pipfile or pyproject.toml")
+	}
+}
+
+func TestResolvePythonUvLock(t *testing.T) {
+	dir := t.TempDir()
+	writePythonFiles(t, dir, pythonVenv)
+	writePythonFiles(t, dir, map[string]string{"uv.lock": `version = 1
+requires-python = ">=3.12"
+
+[[package]]
+name = "certifi"
+version = "2024.2.2"
+source = { registry = "https://pypi.org/simple" }
+sdist = { url = "https://files.pythonhosted.org/certifi-2024.2.2.tar.gz", hash = "sha256:0" }
+
+[[package]]
+name = "charset-normalizer"
+version = "3.3.2"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "foo"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "requests" },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "pytest" },
+]
+
+[[package]]
+name = "idna"
+version = "3.6"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "pytest"
+version = "8.0.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "certifi" },
+    { name = "charset-normalizer" },
+    { name = "idna" },
+]
+wheels = [
+    { url = "https://files.pythonhosted.org/requests-2.31.0-py3-none-any.whl", hash = "sha256:0" },
+]
+`})
+
+	config := &deps.ConfigDeps{Files: []string{filepath.Join(dir, "uv.lock")}, Threshold: deps.DefaultCoverageThreshold}
+	report := deps.Report{}
+	if err := deps.Resolve(config, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"requests":           "Apache-2.0",
+		"certifi":            "MPL-2.0",
+		"charset-normalizer": "MIT",
+	}
+	if len(report.Resolved) != len(expected) {
+		t.Fatalf("expected %d resolved dependencies, got %+v", len(expected), report.Resolved)
+	}
+	for _, result := range report.Resolved {
+		if expected[result.Dependency] != result.LicenseSpdxID {
+			t.Errorf("license of %v = %v, want %v", result.Dependency, result.LicenseSpdxID, expected[result.Dependency])
+		}
+		if result.Direct != (result.Dependency == "requests") {
+			t.Errorf("only requests should be direct, %v is %v", result.Dependency, result.Direct)
+		}
+		if result.Ecosystem != deps.EcosystemPyPI {
+			t.Errorf("ecosystem of %v = %v", result.Dependency, result.Ecosystem)
+		}
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Dependency != "idna" {
+		t.Errorf("idna is not installed and should be skipped, got %+v", report.Skipped)
+	}
+	if paths := report.Paths("idna"); len(paths) != 1 || len(paths[0]) != 2 || paths[0][0] != "requests" {
+		t.Errorf("idna should be introduced by requests, got %v", paths)
+	}
+}
+
+func TestResolvePythonRequirements(t *testing.T) {
+	dir := t.TempDir()
+	writePythonFiles(t, dir, pythonVenv)
+	writePythonFiles(t, dir, map[string]string{"requirements.txt": `#
+# This file is autogenerated by pip-compile with Python 3.12
+#
+certifi==2024.2.2 \
+    --hash=sha256:0
+    # via requests
+charset-normalizer==3.3.2
+    # via requests
+requests[socks]==2.31.0
+    # via -r requirements.in
+-e .
+git+https://github.com/acme/foo.git@v1.0.0#egg=foo
+https://example.com/bar-1.0.0.tar.gz
+./vendor/baz
+`})
+
+	config := &deps.ConfigDeps{PythonVenv: filepath.Join(dir, ".venv"), Threshold: deps.DefaultCoverageThreshold}
+	report := deps.Report{}
+	if err := new(deps.PythonResolver).Resolve(filepath.Join(dir, "requirements.txt"), config, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Resolved) != 3 || len(report.Skipped) != 0 {
+		t.Fatalf("expected 3 resolved dependencies, got %+v %+v", report.Resolved, report.Skipped)
+	}
+	for _, result := range report.Resolved {
+		if result.Direct != (result.Dependency == "requests") {
+			t.Errorf("only requests should be direct, %v is %v", result.Dependency, result.Direct)
+		}
+	}
+	if requests := report.Resolved[2]; requests.Version != "2.31.0" || requests.LicenseContent != "Apache License\n" {
+		t.Errorf("unexpected result %+v", requests)
+	}
+}
+
+func TestLoadPythonPackages(t *testing.T) {
+	dir := t.TempDir()
+	writePythonFiles(t, dir, map[string]string{
+		"poetry.lock": `[[package]]
+name = "requests"
+version = "2.31.0"
+description = "Python HTTP for Humans."
+optional = false
+python-versions = ">=3.7"
+
+[package.dependencies]
+certifi = ">=2017.4.17"
+
+[[package]]
+name = "certifi"
+version = "2024.2.2"
+
+[[package]]
+name = "pytest"
+version = "8.0.0"
+
+[metadata]
+lock-version = "2.0"
+`,
+		"pyproject.toml": `[tool.poetry.dependencies]
+python = "^3.12"
+requests = "^2.31"
+
+[tool.poetry.group.dev.dependencies]
+pytest = "^8.0"
+`,
+		"Pipfile.lock": `{
+  "_meta": {"hash": {"sha256": "0"}},
+  "default": {
+    "requests": {"version": "==2.31.0"},
+    "certifi": {"version": "==2024.2.2"}
+  },
+  "develop": {
+    "pytest": {"version": "==8.0.0"}
+  }
+}`,
+		"Pipfile": `[packages]
+requests = "*"
+
+[dev-packages]
+pytest = "*"
+`,
+	})
+
+	resolver := new(deps.PythonResolver)
+	for _, lock := range []string{"poetry.lock", "Pipfile.lock"} {
+		pkgs, err := resolver.LoadPackages(filepath.Join(dir, lock))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pkgs) != 2 {
+			t.Fatalf("%v: expected requests and certifi, got %+v", lock, pkgs)
+		}
+		for _, pkg := range pkgs {
+			if pkg.Direct != (pkg.Name == "requests") {
+				t.Errorf("%v: only requests should be direct, %v is %v", lock, pkg.Name, pkg.Direct)
+			}
+		}
+	}
+}
@@ -19,6 +19,7 @@ package deps
 
 import (
 	"fmt"
+	"reflect"
 )
 
 type Resolver interface {
@@ -30,9 +31,13 @@ var Resolvers = []Resolver{
 	new(GoModResolver),
 	new(NpmResolver),
 	new(MavenPomResolver),
+	new(GradleResolver),
 	new(JarResolver),
 	new(CargoTomlResolver),
 	new(GemfileLockResolver),
+	new(PythonResolver),
+	new(ComposerResolver),
+	new(NuGetResolver),
 }
 
 func Resolve(config *ConfigDeps, report *Report) error {
@@ -42,9 +47,16 @@ resolveFile:
 			if !resolver.CanResolve(file) {
 				continue
 			}
+			resolved, skipped := len(report.Resolved), len(report.Skipped)
 			if err := resolver.Resolve(file, config, report); err != nil {
 				return err
 			}
+			for _, result := range append(report.Resolved[resolved:], report.Skipped[skipped:]...) {
+				describe(result, resolver, file)
+				if result.Direct {
+					report.Depend(Root, result.Dependency)
+				}
+			}
 			continue resolveFile
 		}
 		return fmt.Errorf("unable to find a resolver to resolve dependency declaration file: %v", file)
@@ -52,3 +64,19 @@ resolveFile:
 
 	return nil
 }
+
+// describe fills the metadata of the result resolved by the resolver from the dependency declaration file.
+func describe(result *Result, resolver Resolver, file string) {
+	if result.Ecosystem == "" {
+		result.Ecosystem = ecosystemOf(resolver)
+	}
+	if result.PURL == "" {
+		result.PURL = PackageURL(result)
+	}
+	if result.Resolver == "" {
+		result.Resolver = reflect.TypeOf(resolver).Elem().Name()
+	}
+	if result.Manifest == "" {
+		result.Manifest = file
+	}
+}
@@ -38,12 +38,26 @@ type Result struct {
 	LicenseSpdxID   string
 	ResolveErrors   []error
 	Version         string
+	// Ecosystem is the package ecosystem of the dependency, named after the package URL type, such as golang and npm.
+	Ecosystem string
+	// PURL is the package URL of the dependency, empty if it cannot be derived.
+	PURL string
+	// Resolver is the name of the resolver that resolves the dependency, such as GoModResolver.
+	Resolver string
+	// Direct is true if the dependency is declared directly in the manifest, false if it's a transitive one.
+	Direct bool
+	// Manifest is the path of the dependency declaration file, such as go.mod and package.json.
+	Manifest string
 }
 
 // Report is a collection of resolved Result.
 type Report struct {
 	Resolved []*Result
 	Skipped  []*Result
+
+	// graph is the children of the dependencies, keyed by the parent name, the direct dependencies are the
+	// children of Root.
+	graph map[string][]string
 }
 
 // Resolve marks the dependency's license is resolved.
@@ -88,3 +102,14 @@ func (report *Report) String() string {
 
 	return s
 }
+
+// Find returns the results of the dependency, which is the name or the package URL (with or without version) of it.
+func (report *Report) Find(name string) []*Result {
+	var results []*Result
+	for _, r := range append(append([]*Result{}, report.Resolved...), report.Skipped...) {
+		if r.Dependency == name || r.PURL != "" && (r.PURL == name || strings.HasPrefix(r.PURL, name+"@")) {
+			results = append(results, r)
+		}
+	}
+	return results
+}
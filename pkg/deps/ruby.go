@@ -89,6 +89,18 @@ func (r *GemfileLockResolver) Resolve(lockfile string, config *ConfigDeps, repor
 		}
 	}
 
+	direct := make(map[string]bool)
+	for _, root := range roots {
+		direct[root] = true
+	}
+	for name := range include {
+		if spec, ok := specs[name]; ok && spec != nil {
+			for _, dep := range spec.Deps {
+				report.Depend(name, dep)
+			}
+		}
+	}
+
 	// Resolve licenses for included gems
 	for name := range include {
 		// Some roots may not exist in the specs graph (e.g., git-sourced gems)
@@ -96,21 +108,21 @@ func (r *GemfileLockResolver) Resolve(lockfile string, config *ConfigDeps, repor
 		if spec, ok := specs[name]; ok && spec != nil {
 			version = spec.Version
 		}
-		if exclude, _ := config.IsExcluded(name, version); exclude {
+		if exclude, _ := config.IsExcluded(EcosystemGem, name, version); exclude {
 			continue
 		}
-		if l, ok := config.GetUserConfiguredLicense(name, version); ok {
-			report.Resolve(&Result{Dependency: name, LicenseSpdxID: l, Version: version})
+		if l, ok := config.GetUserConfiguredLicense(EcosystemGem, name, version); ok {
+			report.Resolve(&Result{Dependency: name, LicenseSpdxID: l, Version: version, Direct: direct[name]})
 			continue
 		}
 
 		licenseID, err := fetchRubyGemsLicense(name, version)
 		if err != nil || licenseID == "" {
 			// Gracefully treat as unresolved license and record in report
-			report.Skip(&Result{Dependency: name, LicenseSpdxID: Unknown, Version: version})
+			report.Skip(&Result{Dependency: name, LicenseSpdxID: Unknown, Version: version, Direct: direct[name]})
 			continue
 		}
-		report.Resolve(&Result{Dependency: name, LicenseSpdxID: licenseID, Version: version})
+		report.Resolve(&Result{Dependency: name, LicenseSpdxID: licenseID, Version: version, Direct: direct[name]})
 	}
 
 	return nil
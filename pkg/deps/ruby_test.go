@@ -97,6 +97,11 @@ func TestRubyGemfileLockResolver(t *testing.T) {
 		if len(report.Resolved)+len(report.Skipped) != 3 {
 			t.Fatalf("expected 3 dependencies, got %d", len(report.Resolved)+len(report.Skipped))
 		}
+		for _, r := range report.Resolved {
+			if want := r.Dependency != "rspec-core"; r.Direct != want {
+				t.Errorf("expected %s to be direct: %v, got %v", r.Dependency, want, r.Direct)
+			}
+		}
 	}
 
 	// Library case: only runtime deps reachable from gemspec (1: rake)
@@ -0,0 +1,189 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// SBOMFormat is the format of the software bill of materials document.
+type SBOMFormat string
+
+const (
+	// SBOMSPDXJSON is the SPDX 2.3 document in JSON.
+	SBOMSPDXJSON SBOMFormat = "spdx-json"
+	// SBOMSPDXTagValue is the SPDX 2.3 document in tag-value.
+	SBOMSPDXTagValue SBOMFormat = "spdx-tv"
+	// SBOMSPDX3JSON is the SPDX 3.0 document in JSON-LD.
+	SBOMSPDX3JSON SBOMFormat = "spdx3-json"
+	// SBOMCycloneDXJSON is the CycloneDX 1.5 BOM in JSON.
+	SBOMCycloneDXJSON SBOMFormat = "cyclonedx-json"
+	// SBOMCycloneDXXML is the CycloneDX 1.5 BOM in XML.
+	SBOMCycloneDXXML SBOMFormat = "cyclonedx-xml"
+)
+
+// SBOMFormats are all the supported SBOM formats.
+var SBOMFormats = []SBOMFormat{SBOMSPDXJSON, SBOMSPDXTagValue, SBOMSPDX3JSON, SBOMCycloneDXJSON, SBOMCycloneDXXML}
+
+// SBOM describes the project whose dependencies are written into the SBOM document.
+type SBOM struct {
+	// Name is the name of the project, the root package of the document.
+	Name string
+	// Version is the version of the project, optional.
+	Version string
+	// License is the SPDX license expression of the project, optional.
+	License string
+	// Supplier is the copyright owner of the project, optional.
+	Supplier string
+	// Tool is the name and the version of the tool that creates the document, such as license-eye-0.6.0.
+	Tool string
+	// Created is the time when the document is created, defaults to now.
+	Created time.Time
+	// Namespace is the unique URI of the document, defaults to a random one.
+	Namespace string
+}
+
+// WriteSBOM writes the report as the SBOM document of the format.
+func WriteSBOM(w io.Writer, format SBOMFormat, report *Report, sbom *SBOM) error {
+	if sbom.Created.IsZero() {
+		sbom.Created = time.Now()
+	}
+	if sbom.Namespace == "" {
+		sbom.Namespace = fmt.Sprintf("https://spdx.org/spdxdocs/%v-%v", sanitizeID(sbom.Name), newUUID())
+	}
+
+	switch format {
+	case SBOMSPDXJSON:
+		return writeSPDXJSON(w, report, sbom)
+	case SBOMSPDXTagValue:
+		return writeSPDXTagValue(w, report, sbom)
+	case SBOMSPDX3JSON:
+		return writeSPDX3JSON(w, report, sbom)
+	case SBOMCycloneDXJSON:
+		return writeCycloneDXJSON(w, report, sbom)
+	case SBOMCycloneDXXML:
+		return writeCycloneDXXML(w, report, sbom)
+	}
+	return fmt.Errorf("unknown SBOM format %q, expected one of %q", format, SBOMFormats)
+}
+
+// ParseSBOMFormat parses the SBOM format.
+func ParseSBOMFormat(format string) (SBOMFormat, error) {
+	for _, f := range SBOMFormats {
+		if string(f) == format {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown SBOM format %q, expected one of %q", format, SBOMFormats)
+}
+
+// sbomResults returns the resolved and the skipped results, sorted by their names and versions.
+func sbomResults(report *Report) []*Result {
+	results := append(append([]*Result{}, report.Resolved...), report.Skipped...)
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Dependency != results[j].Dependency {
+			return results[i].Dependency < results[j].Dependency
+		}
+		return results[i].Version < results[j].Version
+	})
+	return results
+}
+
+// sbomDependencies returns the function that returns the results that the dependency, or the project for Root,
+// depends on in the dependency graph, the project also depends on the results that are not in the graph. If the graph
+// is not captured by the resolvers, the project depends on all the results and the results depend on nothing.
+func sbomDependencies(report *Report, results []*Result) func(name string) []*Result {
+	if !report.HasGraph() {
+		return func(name string) []*Result {
+			if name == Root {
+				return results
+			}
+			return nil
+		}
+	}
+
+	brought := make(map[string]bool)
+	for _, children := range report.graph {
+		for _, child := range children {
+			brought[child] = true
+		}
+	}
+	byName := make(map[string][]*Result)
+	var orphans []*Result
+	for _, result := range results {
+		byName[result.Dependency] = append(byName[result.Dependency], result)
+		if !brought[result.Dependency] {
+			orphans = append(orphans, result)
+		}
+	}
+	return func(name string) []*Result {
+		var dependencies []*Result
+		for _, child := range report.Children(name) {
+			dependencies = append(dependencies, byName[child]...)
+		}
+		if name == Root {
+			dependencies = append(dependencies, orphans...)
+		}
+		return dependencies
+	}
+}
+
+// isSkipped returns whether the license of the result is not resolved.
+func isSkipped(report *Report, result *Result) bool {
+	for _, r := range report.Skipped {
+		if r == result {
+			return true
+		}
+	}
+	return false
+}
+
+// licenseExpression returns the canonical SPDX license expression of the license ID in the result,
+// false if it's not a valid SPDX license expression, such as a license name or Unknown.
+func licenseExpression(id string) (string, bool) {
+	if id == "" || id == Unknown {
+		return "", false
+	}
+	// license.Identify joins the licenses found in the same file by " and "
+	expression, err := license.ParseExpression(strings.ReplaceAll(id, " and ", " AND "))
+	return expression, err == nil
+}
+
+var invalidIDChars = regexp.MustCompile(`[^A-Za-z0-9.\-]+`)
+
+// sanitizeID replaces the characters that are not allowed in the SPDX identifiers.
+func sanitizeID(s string) string {
+	return strings.Trim(invalidIDChars.ReplaceAllString(s, "-"), "-")
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
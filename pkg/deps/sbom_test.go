@@ -0,0 +1,426 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/skywalking-eyes/pkg/deps"
+)
+
+func sbomReport() *deps.Report {
+	report := &deps.Report{}
+	report.Resolve(&deps.Result{
+		Dependency:     "github.com/foo/bar",
+		Version:        "v1.0.0",
+		LicenseSpdxID:  "Apache-2.0",
+		LicenseContent: "Apache License\nVersion 2.0, January 2004\n",
+		Ecosystem:      deps.EcosystemGolang,
+	})
+	report.Resolve(&deps.Result{Dependency: "github.com/foo/dual", Version: "v2.0.0", LicenseSpdxID: "MIT and Apache-2.0", Ecosystem: deps.EcosystemGolang})
+	report.Resolve(&deps.Result{
+		Dependency:     "org.example:custom",
+		Version:        "1.0",
+		LicenseSpdxID:  "The Custom License, Version 1.0",
+		LicenseContent: "Permission is granted to use this software.\n",
+		Ecosystem:      deps.EcosystemMaven,
+	})
+	report.Skip(&deps.Result{
+		Dependency:    "github.com/foo/unknown",
+		Version:       "v0.1.0",
+		LicenseSpdxID: deps.Unknown,
+		ResolveErrors: []error{errors.New("cannot find license file")},
+		Ecosystem:     deps.EcosystemGolang,
+	})
+	return report
+}
+
+func sbomProject() *deps.SBOM {
+	return &deps.SBOM{
+		Name:      "project",
+		License:   "Apache-2.0",
+		Supplier:  "Acme",
+		Tool:      "license-eye-test",
+		Created:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Namespace: "https://example.com/spdxdocs/project",
+	}
+}
+
+func TestWriteSPDXJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := deps.WriteSBOM(&buf, deps.SBOMSPDXJSON, sbomReport(), sbomProject()); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		SPDXVersion       string   `json:"spdxVersion"`
+		DocumentDescribes []string `json:"documentDescribes"`
+		Packages          []struct {
+			Name             string `json:"name"`
+			SPDXID           string `json:"SPDXID"`
+			LicenseDeclared  string `json:"licenseDeclared"`
+			LicenseConcluded string `json:"licenseConcluded"`
+			Comment          string `json:"comment"`
+		} `json:"packages"`
+		Relationships []struct {
+			SpdxElementID      string `json:"spdxElementId"`
+			RelationshipType   string `json:"relationshipType"`
+			RelatedSpdxElement string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+		HasExtractedLicensingInfos []struct {
+			LicenseID     string `json:"licenseId"`
+			ExtractedText string `json:"extractedText"`
+		} `json:"hasExtractedLicensingInfos"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.SPDXVersion != "SPDX-2.3" {
+		t.Errorf("spdxVersion = %v", doc.SPDXVersion)
+	}
+	if len(doc.Packages) != 5 || doc.DocumentDescribes[0] != doc.Packages[0].SPDXID {
+		t.Fatalf("unexpected packages: %+v", doc.Packages)
+	}
+
+	licenses := make(map[string]string)
+	for _, pkg := range doc.Packages {
+		licenses[pkg.Name] = pkg.LicenseDeclared
+	}
+	expected := map[string]string{
+		"project":                "Apache-2.0",
+		"github.com/foo/bar":     "Apache-2.0",
+		"github.com/foo/dual":    "MIT AND Apache-2.0",
+		"github.com/foo/unknown": "NOASSERTION",
+		"org.example:custom":     "LicenseRef-The-Custom-License-Version-1.0",
+	}
+	for name, license := range expected {
+		if licenses[name] != license {
+			t.Errorf("license of %v = %v, want %v", name, licenses[name], license)
+		}
+	}
+	if !strings.Contains(doc.Packages[3].Comment, "cannot find license file") {
+		t.Errorf("the skipped package should be commented: %+v", doc.Packages[3])
+	}
+
+	if len(doc.HasExtractedLicensingInfos) != 1 ||
+		doc.HasExtractedLicensingInfos[0].ExtractedText != "Permission is granted to use this software.\n" {
+		t.Errorf("unexpected extracted licenses: %+v", doc.HasExtractedLicensingInfos)
+	}
+
+	dependsOn := 0
+	for _, r := range doc.Relationships {
+		if r.RelationshipType == "DEPENDS_ON" && r.SpdxElementID == doc.Packages[0].SPDXID {
+			dependsOn++
+		}
+	}
+	if dependsOn != 4 {
+		t.Errorf("the project should depend on 4 packages, got %v", dependsOn)
+	}
+}
+
+// sbomGraphReport returns the report whose dependency graph is captured, except for org.example:custom.
+func sbomGraphReport() *deps.Report {
+	report := sbomReport()
+	report.Depend(deps.Root, "github.com/foo/bar")
+	report.Depend("github.com/foo/bar", "github.com/foo/dual")
+	report.Depend("github.com/foo/dual", "github.com/foo/unknown")
+	return report
+}
+
+func TestWriteSPDXJSONGraph(t *testing.T) {
+	var buf bytes.Buffer
+	if err := deps.WriteSBOM(&buf, deps.SBOMSPDXJSON, sbomGraphReport(), sbomProject()); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Packages []struct {
+			Name   string `json:"name"`
+			SPDXID string `json:"SPDXID"`
+		} `json:"packages"`
+		Relationships []struct {
+			SpdxElementID      string `json:"spdxElementId"`
+			RelationshipType   string `json:"relationshipType"`
+			RelatedSpdxElement string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[string]string)
+	for _, pkg := range doc.Packages {
+		names[pkg.SPDXID] = pkg.Name
+	}
+	var dependsOn []string
+	for _, r := range doc.Relationships {
+		if r.RelationshipType == "DEPENDS_ON" {
+			dependsOn = append(dependsOn, names[r.SpdxElementID]+" -> "+names[r.RelatedSpdxElement])
+		}
+	}
+	expected := []string{
+		"project -> github.com/foo/bar",
+		"project -> org.example:custom",
+		"github.com/foo/bar -> github.com/foo/dual",
+		"github.com/foo/dual -> github.com/foo/unknown",
+	}
+	if strings.Join(dependsOn, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("DEPENDS_ON relationships\n%v\nwant\n%v", strings.Join(dependsOn, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestWriteSPDXTagValue(t *testing.T) {
+	var buf bytes.Buffer
+	if err := deps.WriteSBOM(&buf, deps.SBOMSPDXTagValue, sbomReport(), sbomProject()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"SPDXVersion: SPDX-2.3\n",
+		"DocumentNamespace: https://example.com/spdxdocs/project\n",
+		"Created: 2024-01-02T03:04:05Z\n",
+		"PackageName: github.com/foo/bar\nSPDXID: SPDXRef-Package-github.com-foo-bar-v1.0.0\n",
+		"ExternalRef: PACKAGE-MANAGER purl pkg:golang/github.com/foo/bar@v1.0.0\n",
+		"Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-project\n",
+		"Relationship: SPDXRef-Package-project DEPENDS_ON SPDXRef-Package-github.com-foo-bar-v1.0.0\n",
+		"LicenseID: LicenseRef-The-Custom-License-Version-1.0\nExtractedText: <text>Permission is granted to use this software.\n</text>\n",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("missing %q in:\n%v", line, buf.String())
+		}
+	}
+}
+
+func TestWriteSPDX3JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := deps.WriteSBOM(&buf, deps.SBOMSPDX3JSON, sbomReport(), sbomProject()); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Context string           `json:"@context"`
+		Graph   []map[string]any `json:"@graph"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	types := make(map[string]int)
+	for _, element := range doc.Graph {
+		types[element["type"].(string)]++
+	}
+	if types["software_Package"] != 5 || types["SpdxDocument"] != 1 || types["simplelicensing_SimpleLicensingText"] != 1 {
+		t.Errorf("unexpected elements: %v", types)
+	}
+	if doc.Context != "https://spdx.org/rdf/3.0.1/spdx-context.jsonld" {
+		t.Errorf("@context = %v", doc.Context)
+	}
+}
+
+func TestParseSBOMFormat(t *testing.T) {
+	if _, err := deps.ParseSBOMFormat("spdx-json"); err != nil {
+		t.Error(err)
+	}
+	if _, err := deps.ParseSBOMFormat("spdx-yaml"); err == nil {
+		t.Error("spdx-yaml should be unknown")
+	}
+}
+
+func TestWriteCycloneDXJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := deps.WriteSBOM(&buf, deps.SBOMCycloneDXJSON, sbomReport(), sbomProject()); err != nil {
+		t.Fatal(err)
+	}
+
+	type license struct {
+		License *struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Text *struct {
+				Content string `json:"content"`
+			} `json:"text"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	}
+	var bom struct {
+		BOMFormat   string `json:"bomFormat"`
+		SpecVersion string `json:"specVersion"`
+		Metadata    struct {
+			Component struct {
+				Name     string    `json:"name"`
+				Licenses []license `json:"licenses"`
+			} `json:"component"`
+		} `json:"metadata"`
+		Components []struct {
+			BOMRef     string    `json:"bom-ref"`
+			Name       string    `json:"name"`
+			PURL       string    `json:"purl"`
+			Licenses   []license `json:"licenses"`
+			Properties []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"properties"`
+			Evidence *struct {
+				Licenses []license `json:"licenses"`
+			} `json:"evidence"`
+		} `json:"components"`
+		Dependencies []struct {
+			Ref       string   `json:"ref"`
+			DependsOn []string `json:"dependsOn"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &bom); err != nil {
+		t.Fatal(err)
+	}
+
+	if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != "1.5" {
+		t.Errorf("unexpected BOM format %v %v", bom.BOMFormat, bom.SpecVersion)
+	}
+	if bom.Metadata.Component.Name != "project" || bom.Metadata.Component.Licenses[0].License.ID != "Apache-2.0" {
+		t.Errorf("unexpected metadata component: %+v", bom.Metadata.Component)
+	}
+	if len(bom.Components) != 4 {
+		t.Fatalf("unexpected components: %+v", bom.Components)
+	}
+
+	bar, dual, unknown, custom := bom.Components[0], bom.Components[1], bom.Components[2], bom.Components[3]
+	if bar.PURL != "pkg:golang/github.com/foo/bar@v1.0.0" || bar.BOMRef != bar.PURL {
+		t.Errorf("unexpected purl %v", bar.PURL)
+	}
+	if bar.Licenses[0].License.ID != "Apache-2.0" || bar.Evidence == nil ||
+		bar.Evidence.Licenses[0].License.Text.Content != "Apache License\nVersion 2.0, January 2004\n" {
+		t.Errorf("unexpected licenses of %v: %+v %+v", bar.Name, bar.Licenses, bar.Evidence)
+	}
+	if dual.Licenses[0].Expression != "MIT AND Apache-2.0" || dual.Evidence != nil {
+		t.Errorf("unexpected licenses of %v: %+v %+v", dual.Name, dual.Licenses, dual.Evidence)
+	}
+	if len(unknown.Licenses) != 0 || len(unknown.Properties) != 2 ||
+		unknown.Properties[0].Name != "license-eye:skipped" || unknown.Properties[1].Value != "cannot find license file" {
+		t.Errorf("the skipped component should be flagged: %+v", unknown)
+	}
+	if custom.PURL != "pkg:maven/org.example/custom@1.0" || custom.Licenses[0].License.Name != "The Custom License, Version 1.0" ||
+		custom.Evidence.Licenses[0].License.Text.Content != "Permission is granted to use this software.\n" {
+		t.Errorf("unexpected component: %+v", custom)
+	}
+
+	if len(bom.Dependencies) != 1 || bom.Dependencies[0].Ref != "project" || len(bom.Dependencies[0].DependsOn) != 4 {
+		t.Errorf("unexpected dependencies: %+v", bom.Dependencies)
+	}
+}
+
+func TestWriteCycloneDXXML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := deps.WriteSBOM(&buf, deps.SBOMCycloneDXXML, sbomReport(), sbomProject()); err != nil {
+		t.Fatal(err)
+	}
+
+	var bom struct {
+		XMLName    xml.Name
+		Components []struct {
+			Name       string   `xml:"name"`
+			Licenses   []string `xml:"licenses>expression"`
+			Properties []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"properties>property"`
+		} `xml:"components>component"`
+		Dependencies []struct {
+			Ref       string `xml:"ref,attr"`
+			DependsOn []struct {
+				Ref string `xml:"ref,attr"`
+			} `xml:"dependency"`
+		} `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &bom); err != nil {
+		t.Fatal(err)
+	}
+
+	if bom.XMLName.Space != "http://cyclonedx.org/schema/bom/1.5" || bom.XMLName.Local != "bom" {
+		t.Errorf("unexpected root element %v", bom.XMLName)
+	}
+	if len(bom.Components) != 4 || len(bom.Components[1].Licenses) != 1 || bom.Components[1].Licenses[0] != "MIT AND Apache-2.0" {
+		t.Fatalf("unexpected components: %+v", bom.Components)
+	}
+	if len(bom.Components[2].Properties) != 2 || bom.Components[2].Properties[0].Value != "true" {
+		t.Errorf("the skipped component should be flagged: %+v", bom.Components[2])
+	}
+	if len(bom.Dependencies) != 1 || len(bom.Dependencies[0].DependsOn) != 4 ||
+		bom.Dependencies[0].DependsOn[0].Ref != "pkg:golang/github.com/foo/bar@v1.0.0" {
+		t.Errorf("unexpected dependencies: %+v", bom.Dependencies)
+	}
+}
+
+func TestWriteCycloneDXJSONGraph(t *testing.T) {
+	var buf bytes.Buffer
+	if err := deps.WriteSBOM(&buf, deps.SBOMCycloneDXJSON, sbomGraphReport(), sbomProject()); err != nil {
+		t.Fatal(err)
+	}
+
+	var bom struct {
+		Dependencies []struct {
+			Ref       string   `json:"ref"`
+			DependsOn []string `json:"dependsOn"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &bom); err != nil {
+		t.Fatal(err)
+	}
+
+	var dependencies []string
+	for _, d := range bom.Dependencies {
+		dependencies = append(dependencies, d.Ref+" -> "+strings.Join(d.DependsOn, ","))
+	}
+	expected := []string{
+		"project -> pkg:golang/github.com/foo/bar@v1.0.0,pkg:maven/org.example/custom@1.0",
+		"pkg:golang/github.com/foo/bar@v1.0.0 -> pkg:golang/github.com/foo/dual@v2.0.0",
+		"pkg:golang/github.com/foo/dual@v2.0.0 -> pkg:golang/github.com/foo/unknown@v0.1.0",
+		"pkg:golang/github.com/foo/unknown@v0.1.0 -> ",
+		"pkg:maven/org.example/custom@1.0 -> ",
+	}
+	if strings.Join(dependencies, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("dependencies\n%v\nwant\n%v", strings.Join(dependencies, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestPackageURL(t *testing.T) {
+	tests := []struct {
+		result *deps.Result
+		want   string
+	}{
+		{&deps.Result{Ecosystem: deps.EcosystemGolang, Dependency: "github.com/foo/bar/v2", Version: "v2.0.0+incompatible"},
+			"pkg:golang/github.com/foo/bar/v2@v2.0.0%2Bincompatible"},
+		{&deps.Result{Ecosystem: deps.EcosystemNpm, Dependency: "@babel/core", Version: "7.0.0"}, "pkg:npm/%40babel/core@7.0.0"},
+		{&deps.Result{Ecosystem: deps.EcosystemMaven, Dependency: "org.apache:commons", Version: "1.0"}, "pkg:maven/org.apache/commons@1.0"},
+		{&deps.Result{Ecosystem: deps.EcosystemMaven, Dependency: "commons-1.0.jar", Version: deps.Unknown}, ""},
+		{&deps.Result{Ecosystem: deps.EcosystemCargo, Dependency: "serde", Version: "1.0.0"}, "pkg:cargo/serde@1.0.0"},
+		{&deps.Result{Ecosystem: deps.EcosystemGem, Dependency: "rails"}, "pkg:gem/rails"},
+		{&deps.Result{Dependency: "unknown", Version: "1.0.0"}, ""},
+	}
+	for _, test := range tests {
+		if got := deps.PackageURL(test.result); got != test.want {
+			t.Errorf("PackageURL(%+v) = %v, want %v", test.result, got, test.want)
+		}
+	}
+}
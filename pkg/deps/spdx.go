@@ -0,0 +1,406 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deps
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	spdxVersion     = "SPDX-2.3"
+	spdx3Version    = "3.0.1"
+	spdx3Context    = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+	spdxDataLicense = "CC0-1.0"
+	spdxDocumentID  = "SPDXRef-DOCUMENT"
+	spdxNoAssertion = "NOASSERTION"
+)
+
+type spdxDocument struct {
+	SPDXVersion                string                  `json:"spdxVersion"`
+	DataLicense                string                  `json:"dataLicense"`
+	SPDXID                     string                  `json:"SPDXID"`
+	Name                       string                  `json:"name"`
+	DocumentNamespace          string                  `json:"documentNamespace"`
+	CreationInfo               spdxCreationInfo        `json:"creationInfo"`
+	DocumentDescribes          []string                `json:"documentDescribes"`
+	Packages                   []*spdxPackage          `json:"packages"`
+	Relationships              []*spdxRelationship     `json:"relationships"`
+	HasExtractedLicensingInfos []*spdxExtractedLicense `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string             `json:"name"`
+	SPDXID           string             `json:"SPDXID"`
+	VersionInfo      string             `json:"versionInfo,omitempty"`
+	Supplier         string             `json:"supplier,omitempty"`
+	DownloadLocation string             `json:"downloadLocation"`
+	FilesAnalyzed    bool               `json:"filesAnalyzed"`
+	LicenseConcluded string             `json:"licenseConcluded"`
+	LicenseDeclared  string             `json:"licenseDeclared"`
+	CopyrightText    string             `json:"copyrightText"`
+	Comment          string             `json:"comment,omitempty"`
+	ExternalRefs     []*spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SpdxElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+type spdxExtractedLicense struct {
+	LicenseID     string `json:"licenseId"`
+	ExtractedText string `json:"extractedText"`
+	Name          string `json:"name"`
+}
+
+// spdxBuilder builds the SPDX document of the report.
+type spdxBuilder struct {
+	doc        *spdxDocument
+	ids        map[string]bool
+	extracted  map[string]*spdxExtractedLicense
+	packageIDs map[*Result]string
+}
+
+// newSPDXDocument returns the SPDX 2.3 document of the report, the project is the root package, and the packages
+// depend on each other as in the dependency graph, see sbomDependencies. The licenses that are not SPDX license
+// expressions are extracted as LicenseRef-[name].
+func newSPDXDocument(report *Report, sbom *SBOM) *spdxDocument {
+	b := &spdxBuilder{
+		doc: &spdxDocument{
+			SPDXVersion:       spdxVersion,
+			DataLicense:       spdxDataLicense,
+			SPDXID:            spdxDocumentID,
+			Name:              sbom.Name,
+			DocumentNamespace: sbom.Namespace,
+			CreationInfo: spdxCreationInfo{
+				Created:  sbom.Created.UTC().Format(time.RFC3339),
+				Creators: []string{"Tool: " + sbom.Tool},
+			},
+		},
+		ids:        map[string]bool{spdxDocumentID: true},
+		extracted:  make(map[string]*spdxExtractedLicense),
+		packageIDs: make(map[*Result]string),
+	}
+
+	root := &spdxPackage{
+		Name:             sbom.Name,
+		SPDXID:           b.uniqueID("SPDXRef-Package-" + sanitizeID(sbom.Name)),
+		VersionInfo:      sbom.Version,
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion,
+		LicenseDeclared:  spdxNoAssertion,
+		CopyrightText:    spdxNoAssertion,
+	}
+	if expression, ok := licenseExpression(sbom.License); ok {
+		root.LicenseDeclared = expression
+	}
+	if sbom.Supplier != "" {
+		root.Supplier = "Organization: " + sbom.Supplier
+	}
+	b.doc.Packages = append(b.doc.Packages, root)
+	b.doc.DocumentDescribes = []string{root.SPDXID}
+	b.relate(spdxDocumentID, "DESCRIBES", root.SPDXID)
+
+	results := sbomResults(report)
+	for _, result := range results {
+		pkg := &spdxPackage{
+			Name:             result.Dependency,
+			SPDXID:           b.uniqueID("SPDXRef-Package-" + sanitizeID(result.Dependency+"-"+result.Version)),
+			VersionInfo:      result.Version,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			CopyrightText:    spdxNoAssertion,
+		}
+		if purl := PackageURL(result); purl != "" {
+			pkg.ExternalRefs = []*spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purl}}
+		}
+		if isSkipped(report, result) {
+			pkg.Comment = "The license cannot be resolved" + resolveErrors(result)
+		} else {
+			pkg.LicenseDeclared = b.license(result)
+			pkg.LicenseConcluded = pkg.LicenseDeclared
+		}
+		b.packageIDs[result] = pkg.SPDXID
+		b.doc.Packages = append(b.doc.Packages, pkg)
+	}
+
+	dependencies := sbomDependencies(report, results)
+	for _, dependency := range dependencies(Root) {
+		b.relate(root.SPDXID, "DEPENDS_ON", b.packageIDs[dependency])
+	}
+	for _, result := range results {
+		for _, dependency := range dependencies(result.Dependency) {
+			b.relate(b.packageIDs[result], "DEPENDS_ON", b.packageIDs[dependency])
+		}
+	}
+
+	return b.doc
+}
+
+func (b *spdxBuilder) uniqueID(id string) string {
+	unique := id
+	for i := 2; b.ids[unique]; i++ {
+		unique = fmt.Sprintf("%v-%d", id, i)
+	}
+	b.ids[unique] = true
+	return unique
+}
+
+func (b *spdxBuilder) relate(from, relationship, to string) {
+	b.doc.Relationships = append(b.doc.Relationships, &spdxRelationship{
+		SpdxElementID:      from,
+		RelationshipType:   relationship,
+		RelatedSpdxElement: to,
+	})
+}
+
+// license returns the SPDX license expression of the result, the license that is not an SPDX license expression is
+// extracted as a LicenseRef-[name] with its license content.
+func (b *spdxBuilder) license(result *Result) string {
+	if expression, ok := licenseExpression(result.LicenseSpdxID); ok {
+		return expression
+	}
+	if result.LicenseSpdxID == "" || result.LicenseSpdxID == Unknown {
+		return spdxNoAssertion
+	}
+
+	id := "LicenseRef-" + sanitizeID(result.LicenseSpdxID)
+	if _, ok := b.extracted[id]; !ok {
+		text := result.LicenseContent
+		if strings.TrimSpace(text) == "" {
+			text = result.LicenseSpdxID
+		}
+		info := &spdxExtractedLicense{LicenseID: id, ExtractedText: text, Name: result.LicenseSpdxID}
+		b.extracted[id] = info
+		b.doc.HasExtractedLicensingInfos = append(b.doc.HasExtractedLicensingInfos, info)
+	}
+	return id
+}
+
+func resolveErrors(result *Result) string {
+	if len(result.ResolveErrors) == 0 {
+		return ""
+	}
+	messages := make([]string, len(result.ResolveErrors))
+	for i, err := range result.ResolveErrors {
+		messages[i] = err.Error()
+	}
+	return ": " + strings.Join(messages, "; ")
+}
+
+func writeSPDXJSON(w io.Writer, report *Report, sbom *SBOM) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(newSPDXDocument(report, sbom))
+}
+
+func writeSPDXTagValue(w io.Writer, report *Report, sbom *SBOM) error {
+	doc := newSPDXDocument(report, sbom)
+
+	var sb strings.Builder
+	tag := func(name, value string) {
+		if value == "" {
+			return
+		}
+		if strings.Contains(value, "\n") {
+			value = "<text>" + value + "</text>"
+		}
+		sb.WriteString(name + ": " + value + "\n")
+	}
+
+	tag("SPDXVersion", doc.SPDXVersion)
+	tag("DataLicense", doc.DataLicense)
+	tag("SPDXID", doc.SPDXID)
+	tag("DocumentName", doc.Name)
+	tag("DocumentNamespace", doc.DocumentNamespace)
+	for _, creator := range doc.CreationInfo.Creators {
+		tag("Creator", creator)
+	}
+	tag("Created", doc.CreationInfo.Created)
+
+	for _, pkg := range doc.Packages {
+		sb.WriteString("\n")
+		tag("PackageName", pkg.Name)
+		tag("SPDXID", pkg.SPDXID)
+		tag("PackageVersion", pkg.VersionInfo)
+		tag("PackageSupplier", pkg.Supplier)
+		tag("PackageDownloadLocation", pkg.DownloadLocation)
+		tag("FilesAnalyzed", fmt.Sprint(pkg.FilesAnalyzed))
+		tag("PackageLicenseConcluded", pkg.LicenseConcluded)
+		tag("PackageLicenseDeclared", pkg.LicenseDeclared)
+		tag("PackageCopyrightText", pkg.CopyrightText)
+		for _, ref := range pkg.ExternalRefs {
+			tag("ExternalRef", ref.ReferenceCategory+" "+ref.ReferenceType+" "+ref.ReferenceLocator)
+		}
+		if pkg.Comment != "" {
+			sb.WriteString("PackageComment: <text>" + pkg.Comment + "</text>\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	for _, r := range doc.Relationships {
+		tag("Relationship", r.SpdxElementID+" "+r.RelationshipType+" "+r.RelatedSpdxElement)
+	}
+
+	for _, info := range doc.HasExtractedLicensingInfos {
+		sb.WriteString("\n")
+		tag("LicenseID", info.LicenseID)
+		sb.WriteString("ExtractedText: <text>" + info.ExtractedText + "</text>\n")
+		tag("LicenseName", info.Name)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeSPDX3JSON writes the SPDX 3.0 document in JSON-LD, converted from the SPDX 2.3 one.
+func writeSPDX3JSON(w io.Writer, report *Report, sbom *SBOM) error {
+	doc := newSPDXDocument(report, sbom)
+	ns := sbom.Namespace + "#"
+	const creationInfo = "_:creationinfo"
+
+	supplier := sbom.Supplier
+	if supplier == "" {
+		supplier = spdxNoAssertion
+	}
+	graph := []map[string]any{
+		{
+			"type":         "CreationInfo",
+			"@id":          creationInfo,
+			"specVersion":  spdx3Version,
+			"created":      doc.CreationInfo.Created,
+			"createdBy":    []string{ns + "Organization"},
+			"createdUsing": []string{ns + "Tool"},
+		},
+		{"type": "Organization", "spdxId": ns + "Organization", "name": supplier, "creationInfo": creationInfo},
+		{"type": "Tool", "spdxId": ns + "Tool", "name": sbom.Tool, "creationInfo": creationInfo},
+	}
+	var elements []string
+	add := func(element map[string]any) {
+		element["creationInfo"] = creationInfo
+		graph = append(graph, element)
+		elements = append(elements, element["spdxId"].(string))
+	}
+
+	texts := make(map[string]string)
+	for _, info := range doc.HasExtractedLicensingInfos {
+		texts[info.LicenseID] = ns + info.LicenseID
+		add(map[string]any{
+			"type":                        "simplelicensing_SimpleLicensingText",
+			"spdxId":                      ns + info.LicenseID,
+			"name":                        info.Name,
+			"simplelicensing_licenseText": info.ExtractedText,
+		})
+	}
+
+	expressions := make(map[string]string)
+	licenseOf := func(expression string) string {
+		if id, ok := expressions[expression]; ok {
+			return id
+		}
+		id := fmt.Sprintf("%vLicenseExpression-%d", ns, len(expressions)+1)
+		expressions[expression] = id
+		element := map[string]any{
+			"type":                              "simplelicensing_LicenseExpression",
+			"spdxId":                            id,
+			"simplelicensing_licenseExpression": expression,
+		}
+		var refs []map[string]string
+		for _, token := range strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expression)) {
+			if uri, ok := texts[token]; ok {
+				refs = append(refs, map[string]string{"type": "DictionaryEntry", "key": token, "value": uri})
+			}
+		}
+		if len(refs) > 0 {
+			element["simplelicensing_customIdToUri"] = refs
+		}
+		add(element)
+		return id
+	}
+
+	relationships := 0
+	relate := func(from, relationship string, to ...string) {
+		relationships++
+		add(map[string]any{
+			"type":             "Relationship",
+			"spdxId":           fmt.Sprintf("%vRelationship-%d", ns, relationships),
+			"from":             from,
+			"relationshipType": relationship,
+			"to":               to,
+		})
+	}
+
+	for _, pkg := range doc.Packages {
+		element := map[string]any{"type": "software_Package", "spdxId": ns + pkg.SPDXID, "name": pkg.Name}
+		if pkg.VersionInfo != "" {
+			element["software_packageVersion"] = pkg.VersionInfo
+		}
+		if pkg.Comment != "" {
+			element["comment"] = pkg.Comment
+		}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				element["software_packageUrl"] = ref.ReferenceLocator
+			}
+		}
+		add(element)
+
+		if pkg.LicenseDeclared != spdxNoAssertion {
+			relate(ns+pkg.SPDXID, "hasDeclaredLicense", licenseOf(pkg.LicenseDeclared))
+		}
+		if pkg.LicenseConcluded != spdxNoAssertion {
+			relate(ns+pkg.SPDXID, "hasConcludedLicense", licenseOf(pkg.LicenseConcluded))
+		}
+	}
+	for _, r := range doc.Relationships {
+		if r.RelationshipType == "DEPENDS_ON" {
+			relate(ns+r.SpdxElementID, "dependsOn", ns+r.RelatedSpdxElement)
+		}
+	}
+
+	graph = append(graph, map[string]any{
+		"type":               "SpdxDocument",
+		"spdxId":             ns + spdxDocumentID,
+		"name":               doc.Name,
+		"creationInfo":       creationInfo,
+		"profileConformance": []string{"core", "software", "simpleLicensing"},
+		"rootElement":        []string{ns + doc.DocumentDescribes[0]},
+		"element":            elements,
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]any{"@context": spdx3Context, "@graph": graph})
+}
@@ -19,6 +19,7 @@ package header
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"net/http"
 	"os"
@@ -33,32 +34,44 @@ import (
 	"github.com/bmatcuk/doublestar/v2"
 	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 // Check checks the license headers of the specified paths/globs.
 func Check(config *ConfigHeader, result *Result) error {
-	fileList, err := listFiles(config)
+	fileList, err := ListFiles(config)
 	if err != nil {
 		return err
 	}
 
-	for _, file := range fileList {
-		if err := CheckFile(file, config, result); err != nil {
-			return err
-		}
+	errs := forEachFile(fileList, config.Jobs, func(file string) error {
+		return CheckFile(file, config, result)
+	})
+
+	result.Sort()
+
+	if len(errs) > 0 {
+		return errs[0]
 	}
 
 	return nil
 }
 
-func listFiles(config *ConfigHeader) ([]string, error) {
+// ListFiles lists the candidate files of the config: in a git repository, the files in HEAD (or the ones changed
+// since the configured revision) and the files in the working tree that are not ignored, otherwise the files matching
+// the configured paths. The files are not filtered by the paths and paths-ignore, use ShouldIgnore for that.
+func ListFiles(config *ConfigHeader) ([]string, error) {
 	var fileList []string
 
 	repo, err := git.PlainOpen("./")
 
 	if err != nil { // we're not in a Git workspace, fallback to glob paths
+		if config.Since != "" {
+			return nil, fmt.Errorf("listing files changed since %v requires a git repository: %w", config.Since, err)
+		}
+
 		var localFileList []string
 		for _, pattern := range config.Paths {
 			if pattern == "." {
@@ -92,29 +105,16 @@ func listFiles(config *ConfigHeader) ([]string, error) {
 			candidates = append(candidates, file)
 		}
 
-		head, err := repo.Head()
-		if err != nil || head == nil {
-			// Repository has no commits or invalid HEAD, skip git-based file discovery
-			logger.Log.Debugf("Repository has no commits or invalid HEAD (head: %v), skipping git-based file discovery. Error: %v", head, err)
-		} else {
-			commit, err := repo.CommitObject(head.Hash())
+		if config.Since != "" {
+			changed, err := changedFilesSince(repo, config.Since)
 			if err != nil {
-				logger.Log.Debugln("Failed to get commit object:", err)
-			} else {
-				tree, err := commit.Tree()
-				if err != nil {
-					return nil, err
-				}
-				if err := tree.Files().ForEach(func(file *object.File) error {
-					if file == nil {
-						return errors.New("file pointer is nil")
-					}
-					candidates = append(candidates, file.Name)
-					return nil
-				}); err != nil {
-					return nil, err
-				}
+				return nil, err
 			}
+			candidates = append(candidates, changed...)
+		} else if files, err := headFiles(repo); err != nil {
+			return nil, err
+		} else {
+			candidates = append(candidates, files...)
 		}
 
 		seen := make(map[string]bool)
@@ -134,6 +134,87 @@ func listFiles(config *ConfigHeader) ([]string, error) {
 	return fileList, nil
 }
 
+// headFiles returns all the files in the tree of HEAD.
+func headFiles(repo *git.Repository) ([]string, error) {
+	var files []string
+
+	head, err := repo.Head()
+	if err != nil || head == nil {
+		// Repository has no commits or invalid HEAD, skip git-based file discovery
+		logger.Log.Debugf("Repository has no commits or invalid HEAD (head: %v), skipping git-based file discovery. Error: %v", head, err)
+	} else {
+		commit, err := repo.CommitObject(head.Hash())
+		if err != nil {
+			logger.Log.Debugln("Failed to get commit object:", err)
+		} else {
+			tree, err := commit.Tree()
+			if err != nil {
+				return nil, err
+			}
+			if err := tree.Files().ForEach(func(file *object.File) error {
+				if file == nil {
+					return errors.New("file pointer is nil")
+				}
+				files = append(files, file.Name)
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// changedFilesSince returns the files that are added or modified in HEAD, compared to the given revision.
+// If HEAD and the revision have a common ancestor, the changes are computed against the common ancestor,
+// so that the changes in the revision after the branches forked are not included, just like a pull request.
+func changedFilesSince(repo *git.Repository, revision string) ([]string, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %v: %w", revision, err)
+	}
+	base, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	current, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if ancestors, err := base.MergeBase(current); err == nil && len(ancestors) > 0 {
+		base = ancestors[0]
+	}
+	logger.Log.Debugf("Listing files changed since %v (%v)", revision, base.Hash)
+
+	baseTree, err := base.Tree()
+	if err != nil {
+		return nil, err
+	}
+	currentTree, err := current.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(baseTree, currentTree)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, change := range changes {
+		if change.To.Name != "" { // deleted files have no destination
+			files = append(files, change.To.Name)
+		}
+	}
+	return files, nil
+}
+
 func addIgnorePatterns(t *git.Worktree) {
 	if ignorePattens, err := gitignore.LoadGlobalPatterns(osfs.New("")); err == nil {
 		t.Excludes = append(t.Excludes, ignorePattens...)
@@ -195,29 +276,59 @@ func CheckFile(file string, config *ConfigHeader, result *Result) error {
 		return err
 	}
 
+	if config.isSidecar(file) {
+		result.Ignore(file) // checked along with the file it's named after
+		return nil
+	}
+
 	logger.Log.Debugln("Checking file:", file)
 
 	bs, err := os.ReadFile(file)
 	if err != nil {
 		return err
 	}
+
+	checkContent(file, bs, config, result)
+
+	return nil
+}
+
+// checkContent checks whether the content of the file contains the configured license header.
+func checkContent(file string, bs []byte, config *ConfigHeader, result *Result) {
+	if config.usesSidecar(file, bs) {
+		checkSidecar(file, config, result)
+		return
+	}
+
 	if t := http.DetectContentType(bs); !strings.HasPrefix(t, "text/") {
 		logger.Log.Debugln("Ignoring file:", file, "; type:", t)
-		return nil
+		return
+	}
+
+	checkText(file, bs, config, result)
+}
+
+// checkText checks whether the text contains the configured license header of the file.
+func checkText(file string, bs []byte, config *ConfigHeader, result *Result) {
+	if config.License.Style == LicenseStyleSPDXShort {
+		if d := diagnoseShort(file, string(bs), config); d != nil {
+			result.Fail(d)
+		} else {
+			result.Succeed(file)
+		}
+		return
 	}
 
 	content := lcs.NormalizeHeader(string(bs))
-	expected, pattern := config.NormalizedLicense(), config.NormalizedPattern()
+	expected, pattern := lcs.Normalize(config.licenseContentOf(file)), config.NormalizedPattern()
 
-	if satisfy(content, config, expected, pattern) {
+	if satisfy(content, config, expected, pattern) || satisfyYearPolicy(file, content, config) {
 		result.Succeed(file)
 	} else {
 		logger.Log.Debugln("Content is:", content)
 
-		result.Fail(file)
+		result.Fail(diagnose(file, string(bs), content, config))
 	}
-
-	return nil
 }
 
 func satisfy(content string, config *ConfigHeader, license string, pattern *regexp.Regexp) bool {
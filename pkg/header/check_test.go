@@ -138,7 +138,7 @@ func TestListFilesWithEmptyRepo(t *testing.T) {
 	}
 
 	// This should not panic even with empty repository
-	fileList, err := listFiles(config)
+	fileList, err := ListFiles(config)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -206,7 +206,7 @@ func TestListFilesWithWorktreeDetachedHEAD(t *testing.T) {
 		Paths: []string{"**/*.go"},
 	}
 
-	fileList, err := listFiles(config)
+	fileList, err := ListFiles(config)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -227,7 +227,7 @@ func TestListFilesWithWorktreeDetachedHEAD(t *testing.T) {
 	}
 
 	// This should not panic even with problematic git state
-	fileList2, err := listFiles(config)
+	fileList2, err := ListFiles(config)
 	if err != nil {
 		// It's okay if there's an error, we just don't want a panic
 		t.Logf("Got expected error: %v", err)
@@ -260,7 +260,7 @@ func TestListFilesWithWorktreeDetachedHEAD(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	fileList3, err := listFiles(config)
+	fileList3, err := ListFiles(config)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -269,3 +269,41 @@ func TestListFilesWithWorktreeDetachedHEAD(t *testing.T) {
 		t.Error("Expected to find files with valid commit")
 	}
 }
+
+func TestListFilesSince(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(file string) plumbing.Hash {
+		require.NoError(t, os.WriteFile(file, []byte("package main"), 0o644))
+		_, err := worktree.Add(file)
+		require.NoError(t, err)
+		hash, err := worktree.Commit("add "+file, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com"},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+
+	base := commit("base.go")
+	commit("changed.go")
+	require.NoError(t, os.WriteFile("untracked.go", []byte("package main"), 0o644))
+
+	fileList, err := ListFiles(&ConfigHeader{Paths: []string{"**"}, Since: base.String()})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"changed.go", "untracked.go"}, fileList)
+
+	fileList, err = ListFiles(&ConfigHeader{Paths: []string{"**"}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"base.go", "changed.go", "untracked.go"}, fileList)
+
+	_, err = ListFiles(&ConfigHeader{Paths: []string{"**"}, Since: "no-such-revision"})
+	require.Error(t, err)
+}
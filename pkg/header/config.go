@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -50,6 +51,14 @@ type LicenseConfig struct {
 	SoftwareName   string `yaml:"software-name"`
 	Content        string `yaml:"content"`
 	Pattern        string `yaml:"pattern"`
+
+	// CopyrightYearPolicy decides which copyright year is valid, the copyright-year (or the current year) is required
+	// literally if it's not set.
+	CopyrightYearPolicy CopyrightYearPolicy `yaml:"copyright-year-policy"`
+
+	// Style is the style of the license header, the full license text by default, or spdx-short for the
+	// REUSE-style SPDX-FileCopyrightText and SPDX-License-Identifier tags.
+	Style LicenseStyle `yaml:"style"`
 }
 
 type ConfigHeader struct {
@@ -62,6 +71,33 @@ type ConfigHeader struct {
 	// after all, a "header" cannot be TOO far from the file start.
 	LicenseLocationThreshold int                          `yaml:"license-location-threshold"`
 	Languages                map[string]comments.Language `yaml:"language"`
+
+	// ProtectedHeaders are the license headers that are never replaced by `header fix --replace`, such as the
+	// third-party ones, each of them is an SPDX ID or a regular expression that matches the normalized header.
+	ProtectedHeaders []string `yaml:"protected-headers"`
+
+	// Sidecar makes the `<file>.license` files carry the license headers of the binary files and the files that
+	// cannot have comments, instead of leaving them unchecked.
+	Sidecar bool `yaml:"sidecar"`
+
+	// Jobs is the number of files that are checked (or fixed) concurrently, defaults to the number of CPUs.
+	Jobs int `yaml:"jobs"`
+
+	// Since is a git revision, if it's set, only the files added or modified since the revision are checked.
+	// It's set by the command line flag, not the config file.
+	Since string `yaml:"-"`
+
+	// Replace replaces the existing license headers of other licenses with the configured one when fixing,
+	// instead of inserting a second header. It's set by the command line flag, not the config file.
+	Replace bool `yaml:"-"`
+
+	// DryRun computes the fixes as patches instead of writing them to the files.
+	// It's set by the command line flag, not the config file.
+	DryRun bool `yaml:"-"`
+
+	years      *fileYears
+	identified *identification
+	patterns   *copyrightPatterns
 }
 
 // NormalizedLicense returns the normalized string of the license content,
@@ -169,14 +205,56 @@ func (config *ConfigHeader) Finalize() error {
 		config.LicenseLocationThreshold = 80
 	}
 
+	if config.Jobs <= 0 {
+		config.Jobs = runtime.NumCPU()
+	}
+
+	for _, protected := range config.ProtectedHeaders {
+		if _, err := regexp.Compile(protected); err != nil {
+			return fmt.Errorf("invalid protected header %q: %w", protected, err)
+		}
+	}
+
+	if err := config.License.Style.validate(config); err != nil {
+		return err
+	}
+
+	if err := config.License.CopyrightYearPolicy.validate(); err != nil {
+		return err
+	}
+	if config.License.CopyrightYearPolicy.needsHistory() {
+		config.years = &fileYears{}
+	}
+	config.identified = &identification{}
+	config.patterns = &copyrightPatterns{}
+
 	return nil
 }
 
-func (config *ConfigHeader) GetLicenseContent() (c string) {
-	owner, name, year := config.License.CopyrightOwner, config.License.SoftwareName, config.License.CopyrightYear
-	if year == "" {
-		year = strconv.Itoa(time.Now().Year())
+func (config *ConfigHeader) GetLicenseContent() string {
+	return config.licenseContent(config.copyrightYear(), config.License.CopyrightOwner)
+}
+
+// copyrightYear returns the configured copyright year, or the current year if it's not configured.
+func (config *ConfigHeader) copyrightYear() string {
+	if year := config.License.CopyrightYear; year != "" {
+		return year
 	}
+	return strconv.Itoa(time.Now().Year())
+}
+
+// licenseContentOf returns the license content with the copyright year that should be in the header of the file.
+func (config *ConfigHeader) licenseContentOf(file string) string {
+	return config.licenseContent(config.copyrightYearOf(file, ""), config.License.CopyrightOwner)
+}
+
+// normalizedLicenseWith returns the normalized license content with the given copyright year and owner.
+func (config *ConfigHeader) normalizedLicenseWith(year, owner string) string {
+	return license.Normalize(config.licenseContent(year, owner))
+}
+
+func (config *ConfigHeader) licenseContent(year, owner string) (c string) {
+	name := config.License.SoftwareName
 
 	defer func() {
 		c = strings.ReplaceAll(c, "[year]", year)
@@ -184,6 +262,10 @@ func (config *ConfigHeader) GetLicenseContent() (c string) {
 		c = strings.ReplaceAll(c, "[software-name]", name)
 	}()
 
+	if config.License.Style == LicenseStyleSPDXShort {
+		return config.shortLicenseContent(year, owner)
+	}
+
 	if c = strings.TrimSpace(config.License.Content); c != "" {
 		return config.License.Content // Do not change anything in user config
 	}
@@ -0,0 +1,309 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// Reason is the code of the reason why a file fails the license header check.
+type Reason string
+
+const (
+	// ReasonMissing means there is nothing like the configured license header in the file.
+	ReasonMissing Reason = "missing"
+	// ReasonTooFar means the license header is found, but it's located after the license-location-threshold.
+	ReasonTooFar Reason = "too-far"
+	// ReasonCopyrightYear means the license header is found, but the copyright year is not the configured one.
+	ReasonCopyrightYear Reason = "copyright-year"
+	// ReasonCopyrightOwner means the license header is found, but the copyright owner is not the configured one.
+	ReasonCopyrightOwner Reason = "copyright-owner"
+	// ReasonDifferentLicense means the file has a license header of a different license.
+	ReasonDifferentLicense Reason = "different-license"
+	// ReasonMismatch means the file has a license header that is similar to, but doesn't match the configured one.
+	ReasonMismatch Reason = "mismatch"
+	// ReasonInvalidExpression means the SPDX-License-Identifier of the file is not a valid SPDX license expression.
+	ReasonInvalidExpression Reason = "invalid-expression"
+)
+
+// Reasons are all the failure reasons.
+var Reasons = []Reason{
+	ReasonMissing, ReasonTooFar, ReasonCopyrightYear, ReasonCopyrightOwner, ReasonDifferentLicense, ReasonMismatch,
+	ReasonInvalidExpression,
+}
+
+// Description returns a short human-readable description of the reason.
+func (reason Reason) Description() string {
+	switch reason {
+	case ReasonMissing:
+		return "The license header is missing"
+	case ReasonTooFar:
+		return "The license header is too far from the start of the file"
+	case ReasonCopyrightYear:
+		return "The copyright year in the license header is wrong"
+	case ReasonCopyrightOwner:
+		return "The copyright owner in the license header is wrong"
+	case ReasonDifferentLicense:
+		return "The license header is of a different license"
+	case ReasonMismatch:
+		return "The license header doesn't match the configured one"
+	case ReasonInvalidExpression:
+		return "The SPDX-License-Identifier is not a valid SPDX license expression"
+	}
+	return string(reason)
+}
+
+// Diagnostic describes why a file fails the license header check.
+type Diagnostic struct {
+	File    string
+	Reason  Reason
+	Message string
+	// Offset is the offset of the closest matching header in the normalized content, -1 if not found.
+	Offset int
+	// Line is the 1-based line number of the closest matching header in the file, 0 if not found.
+	Line int
+	// Diff is a short textual diff between the expected (normalized) license header and the closest matching one.
+	Diff string
+}
+
+func (d *Diagnostic) String() string {
+	s := fmt.Sprintf("%s: [%s] %s", d.File, d.Reason, d.Message)
+	if d.Line > 0 {
+		s += fmt.Sprintf(" (line %d)", d.Line)
+	}
+	return s
+}
+
+const (
+	yearPlaceholder  = "licenseeyeyearplaceholder"
+	ownerPlaceholder = "licenseeyeownerplaceholder"
+
+	// diffContextWords is the number of words to show around the first difference in a Diagnostic.Diff.
+	diffContextWords = 6
+	// identifyHeadBytes is the number of leading bytes used to identify a different license.
+	identifyHeadBytes = 4096
+	// identifyThreshold is the minimum coverage to identify a different license from the head of a file.
+	identifyThreshold = 10
+)
+
+var placeholders = regexp.MustCompile(yearPlaceholder + "|" + ownerPlaceholder)
+
+// diagnose finds out why the normalized content doesn't satisfy the config,
+// raw is the original file content that is used to locate the line numbers.
+func diagnose(file, raw, content string, config *ConfigHeader) *Diagnostic {
+	expected := lcs.Normalize(config.licenseContentOf(file))
+	d := &Diagnostic{File: file, Offset: -1}
+
+	if index := strings.Index(content, expected); strings.TrimSpace(expected) != "" && index >= 0 {
+		d.Reason = ReasonTooFar
+		d.Offset = index
+		d.Message = fmt.Sprintf("the license header is found at offset %d, exceeding the license-location-threshold %d",
+			index, config.LicenseLocationThreshold)
+		d.Line = lineOf(raw, content[index:])
+		return d
+	}
+	if pattern := config.NormalizedPattern(); pattern != nil {
+		if index := pattern.FindStringIndex(content); len(index) == 2 {
+			d.Reason = ReasonTooFar
+			d.Offset = index[0]
+			d.Message = fmt.Sprintf("the license header pattern is matched at offset %d, exceeding the license-location-threshold %d",
+				index[0], config.LicenseLocationThreshold)
+			d.Line = lineOf(raw, content[index[0]:])
+			return d
+		}
+	}
+
+	if diagnoseCopyright(d, raw, content, config) {
+		return d
+	}
+
+	if found, ok := differentLicense(raw, config); ok {
+		d.Reason = ReasonDifferentLicense
+		d.Message = fmt.Sprintf("found a license header of %s", found)
+		if id := config.License.SpdxID; id != "" {
+			d.Message += ", expected " + id
+		}
+		return d
+	}
+
+	if index := closestHeader(content, expected); index >= 0 {
+		d.Reason = ReasonMismatch
+		d.Offset = index
+		d.Message = "the license header doesn't match the configured one"
+		if config.NormalizedPattern() != nil {
+			d.Message += " or pattern"
+		}
+		d.Line = lineOf(raw, content[index:])
+		d.Diff = shortDiff(expected, content[index:])
+		return d
+	}
+
+	d.Reason = ReasonMissing
+	d.Message = "the license header is missing"
+	return d
+}
+
+// diagnoseCopyright checks whether the header matches the license when the copyright year and owner are ignored,
+// if so, the mismatched one is recorded into the diagnostic.
+func diagnoseCopyright(d *Diagnostic, raw, content string, config *ConfigHeader) bool {
+	m := matchCopyright(content, config)
+	if m == nil {
+		return false
+	}
+	// the header that fix writes, with the copyright year derived from the found one
+	expected := config.normalizedLicenseWith(config.copyrightYearOf(d.File, m.Year), config.License.CopyrightOwner)
+
+	d.Offset = m.Offset
+	d.Line = lineOf(raw, content[m.Offset:])
+
+	owner := lcs.Normalize(config.License.CopyrightOwner)
+	switch {
+	case m.Year != "" && !config.acceptsYear(d.File, m.Year):
+		d.Reason = ReasonCopyrightYear
+		d.Message = fmt.Sprintf("the copyright year is %q, expected %q", m.Year, config.copyrightYearOf(d.File, m.Year))
+		if config.License.CopyrightYearPolicy == YearPolicyAny {
+			d.Message = fmt.Sprintf("the copyright year is %q, expected a year or year range", m.Year)
+		}
+		d.Diff = shortDiff(expected, content[m.Offset:])
+	case m.Owner != "" && m.Owner != owner:
+		d.Reason = ReasonCopyrightOwner
+		d.Message = fmt.Sprintf("the copyright owner is %q, expected %q", m.Owner, owner)
+		d.Diff = shortDiff(expected, content[m.Offset:])
+	default:
+		// the year and owner are the same, so the header must be too far from the file start
+		d.Reason = ReasonTooFar
+		d.Message = fmt.Sprintf("the license header is found at offset %d, exceeding the license-location-threshold %d",
+			m.Offset, config.LicenseLocationThreshold)
+	}
+	return true
+}
+
+// differentLicense identifies the license in the head of the raw content, and checks whether it's different from
+// the configured one, the configured license is identified from the content if the spdx-id is not set.
+func differentLicense(raw string, config *ConfigHeader) (string, bool) {
+	head := raw
+	if len(head) > identifyHeadBytes {
+		head = head[:identifyHeadBytes]
+	}
+	found, err := lcs.Identify(head, identifyThreshold)
+	if err != nil {
+		return "", false
+	}
+
+	if isLicense(found, config) {
+		return "", false
+	}
+	return found, true
+}
+
+// isLicense returns whether the identified license, which may be a list of licenses joined by " and ", is the
+// configured one, the IDs are compared exactly, except for the "-only" suffix that the identified IDs may go without.
+// The configured license is identified from the content (once) if the spdx-id is not set.
+func isLicense(found string, config *ConfigHeader) bool {
+	expected := config.License.SpdxID
+	if expected == "" {
+		expected = config.identifiedLicense()
+	}
+	expected = strings.TrimSuffix(expected, "-only")
+	return expected != "" && slices.ContainsFunc(strings.Split(found, " and "), func(id string) bool {
+		return strings.TrimSuffix(id, "-only") == expected
+	})
+}
+
+// identification is the SPDX ID identified from the configured license content.
+type identification struct {
+	once sync.Once
+	id   string
+}
+
+// identifiedLicense returns the SPDX ID identified from the configured license content, or empty if it cannot be
+// identified, the content is identified only once.
+func (config *ConfigHeader) identifiedLicense() string {
+	if config.identified == nil {
+		id, _ := lcs.Identify(config.GetLicenseContent(), identifyThreshold)
+		return id
+	}
+	config.identified.once.Do(func() {
+		config.identified.id, _ = lcs.Identify(config.GetLicenseContent(), identifyThreshold)
+	})
+	return config.identified.id
+}
+
+// closestHeader returns the index of the content where the expected license header most likely starts,
+// the header is considered to start from the first occurrence of the leading words of the expected license.
+func closestHeader(content, expected string) int {
+	words := strings.Fields(expected)
+	for n := min(4, len(words)); n >= 2; n-- {
+		if index := strings.Index(content, strings.Join(words[:n], " ")); index >= 0 {
+			return index
+		}
+	}
+	return -1
+}
+
+// lineOf returns the 1-based line number of the raw content where the normalized text starts, 0 if not found.
+func lineOf(raw, normalized string) int {
+	words := strings.Fields(normalized)
+	if len(words) == 0 {
+		return 0
+	}
+	prefix := strings.Join(words[:min(2, len(words))], " ")
+	for i, line := range strings.Split(raw, "\n") {
+		if strings.Contains(lcs.Normalize(line), prefix) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// shortDiff returns the words around the first difference between the expected and actual normalized texts.
+func shortDiff(expected, actual string) string {
+	e, a := strings.Fields(expected), strings.Fields(actual)
+
+	i := 0
+	for i < len(e) && i < len(a) && e[i] == a[i] {
+		i++
+	}
+	if i == len(e) {
+		return ""
+	}
+
+	start := max(0, i-diffContextWords/2)
+	snippet := func(words []string) string {
+		if start >= len(words) {
+			return "<end of file>"
+		}
+		end := min(len(words), i+diffContextWords)
+		s := strings.Join(words[start:end], " ")
+		if start > 0 {
+			s = "... " + s
+		}
+		if end < len(words) {
+			s += " ..."
+		}
+		return s
+	}
+
+	return fmt.Sprintf("- %s\n+ %s", snippet(e), snippet(a))
+}
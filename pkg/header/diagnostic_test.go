@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+
+	"github.com/stretchr/testify/require"
+)
+
+const apacheHeader = `// Copyright %s %s
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+`
+
+const mitHeader = `// Copyright (c) 2020 Someone
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+`
+
+func TestDiagnose(t *testing.T) {
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"}}
+	require.NoError(t, c.Finalize())
+
+	header := func(year, owner string) string {
+		return strings.Replace(strings.Replace(apacheHeader, "%s", year, 1), "%s", owner, 1)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		reason  Reason
+		line    int
+		diff    bool
+	}{
+		{
+			name:    "Missing",
+			content: "package main\n\nfunc main() {}\n",
+			reason:  ReasonMissing,
+		},
+		{
+			name:    "TooFar",
+			content: "package main\n\n// some comments that are long enough to push the license header away from the start of the file\n\n" + header("2024", "Acme"),
+			reason:  ReasonTooFar,
+			line:    5,
+		},
+		{
+			name:    "CopyrightYear",
+			content: header("2019", "Acme") + "\npackage main\n",
+			reason:  ReasonCopyrightYear,
+			line:    1,
+			diff:    true,
+		},
+		{
+			name:    "CopyrightOwner",
+			content: header("2024", "Someone Else") + "\npackage main\n",
+			reason:  ReasonCopyrightOwner,
+			line:    1,
+			diff:    true,
+		},
+		{
+			name:    "DifferentLicense",
+			content: mitHeader + "\npackage main\n",
+			reason:  ReasonDifferentLicense,
+		},
+		{
+			name: "Mismatch",
+			content: strings.Replace(header("2024", "Acme"), "WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND",
+				"WITHOUT ANY WARRANTIES", 1) + "\npackage main\n",
+			reason: ReasonMismatch,
+			line:   1,
+			diff:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := lcs.NormalizeHeader(tt.content)
+			require.False(t, satisfy(content, c, c.NormalizedLicense(), c.NormalizedPattern()))
+
+			d := diagnose("test.go", tt.content, content, c)
+			require.Equal(t, tt.reason, d.Reason, d.Message)
+			require.Equal(t, tt.line, d.Line)
+			require.Equal(t, tt.diff, d.Diff != "", d.Diff)
+		})
+	}
+}
+
+func TestShortDiff(t *testing.T) {
+	require.Equal(t, "", shortDiff("a b c", "a b c d"))
+	require.Equal(t, "- a b c\n+ a b x", shortDiff("a b c", "a b x"))
+	require.Equal(t, "- ... d e f g h i j k l ...\n+ ... d e f x", shortDiff("a b c d e f g h i j k l m n", "a b c d e f x"))
+	require.Equal(t, "- a b c\n+ <end of file>", shortDiff("a b c", ""))
+}
+
+func TestDiagnoseCopyrightYearRange(t *testing.T) {
+	c := &ConfigHeader{License: LicenseConfig{
+		SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2019", CopyrightYearPolicy: YearPolicyRangeToCurrent,
+	}}
+	require.NoError(t, c.Finalize())
+
+	// the earlier start year of the existing range is kept
+	raw := strings.Replace(strings.Replace(apacheHeader, "%s", "2015-2020", 1), "%s", "Acme", 1) + "\npackage main\n"
+	d := diagnose("test.go", raw, lcs.NormalizeHeader(raw), c)
+
+	expected := "2015-" + strconv.Itoa(time.Now().Year())
+	require.Equal(t, ReasonCopyrightYear, d.Reason, d.Message)
+	require.Contains(t, d.Message, expected)
+	require.Contains(t, d.Diff, "- copyright "+expected+" acme")
+	require.Contains(t, d.Diff, "+ copyright 2015-2020 acme")
+}
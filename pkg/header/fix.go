@@ -36,13 +36,84 @@ func Fix(file string, config *ConfigHeader, result *Result) error {
 		return err
 	}
 
+	stat, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if config.usesSidecar(file, content) {
+		return fixSidecar(file, r.Diagnostic(file).Reason, config, result)
+	}
+
+	fixed, err := fixContent(file, content, r.Diagnostic(file).Reason, config)
+	if err != nil {
+		return err
+	}
+
+	return writeFix(file, content, fixed, stat.Mode(), config, result)
+}
+
+// writeFix writes the fixed content into the file, or records the patch of it in the dry-run mode.
+func writeFix(file string, original, fixed []byte, mode os.FileMode, config *ConfigHeader, result *Result) error {
+	if config.DryRun {
+		result.Patch(file, unifiedDiff(file, original, fixed))
+		return nil
+	}
+
+	if err := os.WriteFile(file, fixed, mode); err != nil {
+		return err
+	}
+
+	result.Fix(file)
+
+	return nil
+}
+
+// fixContent returns the content of the file with the license header fixed, according to why it fails the check:
+// the existing SPDX tags (in the spdx-short style) or the copyright year (if it's the only problem) are rewritten
+// in place, the existing license header of other licenses is replaced in the replace mode, otherwise the configured
+// license header is inserted.
+func fixContent(file string, content []byte, reason Reason, config *ConfigHeader) ([]byte, error) {
+	if config.License.Style == LicenseStyleSPDXShort {
+		if fixed, ok := rewriteShortTags(file, content, config); ok {
+			return fixed, nil
+		}
+	} else if reason == ReasonCopyrightYear {
+		if fixed, ok := rewriteCopyrightYear(file, content, config); ok {
+			return fixed, nil
+		}
+	}
+
 	style := comments.FileCommentStyle(file)
 
 	if style == nil {
-		return fmt.Errorf("unsupported file: %v", file)
+		return nil, fmt.Errorf("unsupported file: %v", file)
+	}
+
+	if config.Replace {
+		if fixed, ok, err := replaceHeader(file, style, content, config); ok || err != nil {
+			return fixed, err
+		}
 	}
 
-	return InsertComment(file, style, config, result)
+	return insertHeader(file, style, content, config)
+}
+
+// FixFiles fixes the given files concurrently with the configured number of jobs,
+// and returns the errors of all the files that failed to be fixed.
+func FixFiles(files []string, config *ConfigHeader, result *Result) []error {
+	errs := forEachFile(files, config.Jobs, func(file string) error {
+		return Fix(file, config, result)
+	})
+
+	result.Sort()
+
+	return errs
 }
 
 func InsertComment(file string, style *comments.CommentStyle, config *ConfigHeader, result *Result) error {
@@ -56,13 +127,11 @@ func InsertComment(file string, style *comments.CommentStyle, config *ConfigHead
 		return err
 	}
 
-	licenseHeader, err := GenerateLicenseHeader(style, config)
+	content, err = insertHeader(file, style, content, config)
 	if err != nil {
 		return err
 	}
 
-	content = rewriteContent(style, content, licenseHeader, config.LicensePattern(style))
-
 	if err := os.WriteFile(file, content, stat.Mode()); err != nil {
 		return err
 	}
@@ -72,6 +141,16 @@ func InsertComment(file string, style *comments.CommentStyle, config *ConfigHead
 	return nil
 }
 
+// insertHeader returns the content with the configured license header of the file inserted.
+func insertHeader(file string, style *comments.CommentStyle, content []byte, config *ConfigHeader) ([]byte, error) {
+	licenseHeader, err := generateLicenseHeader(style, config.licenseContentOf(file))
+	if err != nil {
+		return nil, err
+	}
+
+	return rewriteContent(style, content, licenseHeader, config.LicensePattern(style)), nil
+}
+
 func rewriteContent(style *comments.CommentStyle, content []byte, licenseHeader string, licensePattern *regexp.Regexp) []byte {
 	// Remove previous license header version to allow update it
 	if licensePattern != nil {
@@ -101,11 +180,14 @@ func rewriteContent(style *comments.CommentStyle, content []byte, licenseHeader
 }
 
 func GenerateLicenseHeader(style *comments.CommentStyle, config *ConfigHeader) (string, error) {
+	return generateLicenseHeader(style, config.GetLicenseContent())
+}
+
+func generateLicenseHeader(style *comments.CommentStyle, content string) (string, error) {
 	if err := style.Validate(); err != nil {
 		return "", err
 	}
 
-	content := config.GetLicenseContent()
 	// Trim leading and trailing newlines
 	content = strings.TrimSpace(content)
 	lines := strings.Split(content, "\n")
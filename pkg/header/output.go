@@ -0,0 +1,358 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format is the format to output the header check results in.
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+	FormatJUnit Format = "junit"
+)
+
+// Formats are all the supported output formats.
+var Formats = []Format{FormatText, FormatJSON, FormatSARIF, FormatJUnit}
+
+// Report is the check result of a single header section in the config file.
+type Report struct {
+	// Index is the index of the header section in the config file.
+	Index  int
+	Config *ConfigHeader
+	Result *Result
+}
+
+// ParseFormat validates and returns the output format.
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(s) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format %q, supported formats are %v", s, Formats)
+}
+
+// WriteReports writes the reports to w in the given format.
+func WriteReports(w io.Writer, format Format, reports []*Report) error {
+	switch format {
+	case FormatText:
+		return writeText(w, reports)
+	case FormatJSON:
+		return writeJSON(w, reports)
+	case FormatSARIF:
+		return writeSARIF(w, reports)
+	case FormatJUnit:
+		return writeJUnit(w, reports)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+func writeText(w io.Writer, reports []*Report) error {
+	for _, report := range reports {
+		if _, err := fmt.Fprintln(w, report.Result.String()); err != nil {
+			return err
+		}
+		if report.Result.HasFailure() {
+			if _, err := fmt.Fprintln(w, report.Result.Error()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// -------- JSON --------
+
+type jsonFailure struct {
+	File    string `json:"file"`
+	Reason  Reason `json:"reason"`
+	Message string `json:"message"`
+	Offset  int    `json:"offset"`
+	Line    int    `json:"line,omitempty"`
+	Diff    string `json:"diff,omitempty"`
+}
+
+type jsonHeader struct {
+	Index   int           `json:"index"`
+	License string        `json:"license,omitempty"`
+	Success []string      `json:"success"`
+	Failure []jsonFailure `json:"failure"`
+	Ignored []string      `json:"ignored"`
+	Fixed   []string      `json:"fixed"`
+}
+
+type jsonSummary struct {
+	Total   int `json:"total"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+	Ignored int `json:"ignored"`
+	Fixed   int `json:"fixed"`
+}
+
+type jsonReport struct {
+	Headers []jsonHeader `json:"headers"`
+	Summary jsonSummary  `json:"summary"`
+}
+
+func writeJSON(w io.Writer, reports []*Report) error {
+	out := jsonReport{Headers: make([]jsonHeader, 0, len(reports))}
+	for _, report := range reports {
+		result := report.Result
+		h := jsonHeader{
+			Index:   report.Index,
+			License: report.Config.License.SpdxID,
+			Success: nonNil(result.Success),
+			Failure: make([]jsonFailure, 0, len(result.Failure)),
+			Ignored: nonNil(result.Ignored),
+			Fixed:   nonNil(result.Fixed),
+		}
+		for _, file := range result.Failure {
+			d := result.Diagnostic(file)
+			h.Failure = append(h.Failure, jsonFailure{
+				File:    file,
+				Reason:  d.Reason,
+				Message: d.Message,
+				Offset:  d.Offset,
+				Line:    d.Line,
+				Diff:    d.Diff,
+			})
+		}
+		out.Headers = append(out.Headers, h)
+
+		out.Summary.Valid += len(result.Success)
+		out.Summary.Invalid += len(result.Failure)
+		out.Summary.Ignored += len(result.Ignored)
+		out.Summary.Fixed += len(result.Fixed)
+	}
+	out.Summary.Total = out.Summary.Valid + out.Summary.Invalid + out.Summary.Ignored
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+func nonNil(files []string) []string {
+	if files == nil {
+		return []string{}
+	}
+	return files
+}
+
+// -------- SARIF --------
+
+const (
+	sarifSchema     = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion    = "2.1.0"
+	sarifRulePrefix = "license-header/"
+	toolURI         = "https://github.com/apache/skywalking-eyes"
+)
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+func writeSARIF(w io.Writer, reports []*Report) error {
+	rules := make([]sarifRule, 0, len(Reasons))
+	for _, reason := range Reasons {
+		rules = append(rules, sarifRule{
+			ID:               sarifRulePrefix + string(reason),
+			ShortDescription: sarifMessage{Text: reason.Description()},
+			HelpURI:          toolURI,
+		})
+	}
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "license-eye",
+			InformationURI: toolURI,
+			Rules:          rules,
+		}},
+		Results: make([]sarifResult, 0),
+	}
+
+	for _, report := range reports {
+		for _, file := range report.Result.Failure {
+			d := report.Result.Diagnostic(file)
+			message := d.Message
+			if d.Diff != "" {
+				message += "\n" + d.Diff
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  sarifRulePrefix + string(d.Reason),
+				Level:   "error",
+				Message: sarifMessage{Text: message},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)},
+					Region:           sarifRegion{StartLine: max(1, d.Line)},
+				}}},
+				Properties: map[string]any{"headerIndex": report.Index},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+// -------- JUnit --------
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Details string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+func writeJUnit(w io.Writer, reports []*Report) error {
+	suites := junitTestSuites{Name: "license-eye"}
+	for _, report := range reports {
+		result := report.Result
+		name := fmt.Sprintf("header[%d]", report.Index)
+		if id := report.Config.License.SpdxID; id != "" {
+			name += " " + id
+		}
+		suite := junitTestSuite{
+			Name:     name,
+			Tests:    len(result.Success) + len(result.Failure) + len(result.Ignored),
+			Failures: len(result.Failure),
+			Skipped:  len(result.Ignored),
+		}
+		for _, file := range result.Success {
+			suite.TestCases = append(suite.TestCases, junitTestCase{Name: file, ClassName: name})
+		}
+		for _, file := range result.Failure {
+			d := result.Diagnostic(file)
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      file,
+				ClassName: name,
+				Failure:   &junitFailure{Message: d.Message, Type: string(d.Reason), Details: d.Diff},
+			})
+		}
+		for _, file := range result.Ignored {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      file,
+				ClassName: name,
+				Skipped:   &junitSkipped{Message: "ignored by the config"},
+			})
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.TestSuites = append(suites.TestSuites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testReports() []*Report {
+	return []*Report{
+		{
+			Index:  0,
+			Config: &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0"}},
+			Result: &Result{
+				Success: []string{"a.go"},
+				Failure: []string{"b.go", "c.py"},
+				Ignored: []string{"d.png"},
+			},
+		},
+		{
+			Index:  1,
+			Config: &ConfigHeader{License: LicenseConfig{SpdxID: "MIT"}},
+			Result: &Result{Success: []string{"e.go"}},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		format, err := ParseFormat(string(f))
+		require.NoError(t, err)
+		require.Equal(t, f, format)
+	}
+	format, err := ParseFormat("SARIF")
+	require.NoError(t, err)
+	require.Equal(t, FormatSARIF, format)
+
+	_, err = ParseFormat("yaml")
+	require.Error(t, err)
+}
+
+func TestWriteReportsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteReports(&buf, FormatJSON, testReports()))
+
+	var out jsonReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	require.Len(t, out.Headers, 2)
+	require.Equal(t, 1, out.Headers[1].Index)
+	require.Equal(t, "Apache-2.0", out.Headers[0].License)
+	require.Equal(t, []string{"d.png"}, out.Headers[0].Ignored)
+	require.Equal(t, "b.go", out.Headers[0].Failure[0].File)
+	require.NotEmpty(t, out.Headers[0].Failure[0].Reason)
+	require.Equal(t, jsonSummary{Total: 5, Valid: 2, Invalid: 2, Ignored: 1}, out.Summary)
+}
+
+func TestWriteReportsSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteReports(&buf, FormatSARIF, testReports()))
+
+	var out sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	require.Equal(t, sarifVersion, out.Version)
+	require.Len(t, out.Runs, 1)
+	require.Len(t, out.Runs[0].Results, 2)
+	require.Equal(t, "c.py", out.Runs[0].Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestWriteReportsJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteReports(&buf, FormatJUnit, testReports()))
+
+	var out junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &out))
+	require.Len(t, out.TestSuites, 2)
+	require.Equal(t, 5, out.Tests)
+	require.Equal(t, 2, out.Failures)
+	require.Equal(t, 1, out.Skipped)
+	require.Equal(t, "header[0] Apache-2.0", out.TestSuites[0].Name)
+	require.NotNil(t, out.TestSuites[0].TestCases[1].Failure)
+}
@@ -0,0 +1,61 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"sync"
+)
+
+// forEachFile calls fn on each of the files with at most jobs goroutines,
+// the returned errors are in the same order as the files that caused them.
+func forEachFile(files []string, jobs int, fn func(file string) error) []error {
+	if jobs <= 0 {
+		jobs = 1
+	}
+	if jobs > len(files) {
+		jobs = len(files)
+	}
+
+	errs := make([]error, len(files))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				errs[index] = fn(files[index])
+			}
+		}()
+	}
+
+	for i := range files {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	var result []error
+	for _, err := range errs {
+		if err != nil {
+			result = append(result, err)
+		}
+	}
+	return result
+}
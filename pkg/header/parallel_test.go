@@ -0,0 +1,70 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachFile(t *testing.T) {
+	var files []string
+	for i := 0; i < 100; i++ {
+		files = append(files, fmt.Sprintf("file-%03d", i))
+	}
+
+	for _, jobs := range []int{0, 1, 4, 200} {
+		t.Run(fmt.Sprintf("jobs=%d", jobs), func(t *testing.T) {
+			var count int32
+			errs := forEachFile(files, jobs, func(file string) error {
+				atomic.AddInt32(&count, 1)
+				if file == "file-010" || file == "file-090" {
+					return fmt.Errorf("failed: %s", file)
+				}
+				return nil
+			})
+			require.Equal(t, int32(len(files)), count)
+			require.Len(t, errs, 2)
+			require.EqualError(t, errs[0], "failed: file-010")
+			require.EqualError(t, errs[1], "failed: file-090")
+		})
+	}
+}
+
+func TestResultConcurrentAppend(t *testing.T) {
+	var files []string
+	for i := 0; i < 100; i++ {
+		files = append(files, fmt.Sprintf("file-%03d", 99-i))
+	}
+
+	var result Result
+	_ = forEachFile(files, 8, func(file string) error {
+		result.Succeed(file)
+		result.Fail(&Diagnostic{File: file, Reason: ReasonMissing})
+		return nil
+	})
+	result.Sort()
+
+	require.Len(t, result.Success, 100)
+	require.Len(t, result.Failure, 100)
+	require.IsIncreasing(t, result.Success)
+	require.IsIncreasing(t, result.Failure)
+}
@@ -0,0 +1,82 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// unifiedDiffContext is the number of context lines around the changes in the unified diffs.
+const unifiedDiffContext = 3
+
+// unifiedDiff returns the unified diff between the original and fixed contents of the file,
+// with the a/ and b/ prefixes in the file names, so that it can be applied by `git apply`.
+// The nil original content means the file is to be created.
+func unifiedDiff(file string, original, fixed []byte) string {
+	fromFile := "a/" + file
+	if original == nil {
+		fromFile = "/dev/null"
+	}
+	// the diff can only fail when writing to the buffer, which never happens
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(string(original)),
+		B:        diffLines(string(fixed)),
+		FromFile: fromFile,
+		ToFile:   "b/" + file,
+		Context:  unifiedDiffContext,
+	})
+	return diff
+}
+
+// diffLines splits the content into lines, keeping the line breaks, the last line without
+// a line break is marked, as the format of unified diffs requires.
+func diffLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	return lines
+}
+
+// WritePatches writes the patches of all the results into a single patch, ordered by the file paths.
+func WritePatches(w io.Writer, results ...*Result) error {
+	patches := make(map[string]string)
+	for _, result := range results {
+		for file, patch := range result.Patches {
+			patches[file] = patch
+		}
+	}
+
+	files := make([]string, 0, len(patches))
+	for file := range patches {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		if _, err := io.WriteString(w, patches[file]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	require.Equal(t, `--- a/run.sh
++++ b/run.sh
+@@ -1,2 +1,3 @@
+ #!/bin/sh
++# header
+ echo hi
+\ No newline at end of file
+`, unifiedDiff("run.sh", []byte("#!/bin/sh\necho hi"), []byte("#!/bin/sh\n# header\necho hi")))
+
+	require.Equal(t, `--- a/main.go
++++ b/main.go
+@@ -1 +1,2 @@
++// header
+ package main
+`, unifiedDiff("main.go", []byte("package main\n"), []byte("// header\npackage main\n")))
+
+	require.Empty(t, unifiedDiff("main.go", []byte("package main\n"), []byte("package main\n")))
+}
+
+func TestFixDryRun(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(file, []byte("package main\n"), 0o644))
+
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"}, DryRun: true}
+	require.NoError(t, c.Finalize())
+
+	result := &Result{}
+	require.Empty(t, FixFiles([]string{file}, c, result))
+	require.Equal(t, []string{file}, result.Fixed)
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", string(content), "the file must not be written in the dry-run mode")
+
+	patch := result.Patches[file]
+	require.True(t, strings.HasPrefix(patch, "--- a/"+file+"\n+++ b/"+file+"\n@@ -1 +1,15 @@\n+// Copyright 2024 Acme\n"), patch)
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePatches(&buf, &Result{Patches: map[string]string{"b": "b\n"}}, &Result{Patches: map[string]string{"a": "a\n"}}))
+	require.Equal(t, "a\nb\n", buf.String())
+}
@@ -0,0 +1,177 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// Remove removes the license headers described by the config, i.e. matching the pattern or of the SPDX ID,
+// from the files in the configured paths.
+func Remove(config *ConfigHeader, result *Result) []error {
+	fileList, err := ListFiles(config)
+	if err != nil {
+		return []error{err}
+	}
+
+	errs := forEachFile(fileList, config.Jobs, func(file string) error {
+		return RemoveFile(file, config, result)
+	})
+
+	result.Sort()
+
+	return errs
+}
+
+// RemoveFile removes the license header described by the config from the file.
+func RemoveFile(file string, config *ConfigHeader, result *Result) error {
+	style, content, mode, err := readCommentable(file, config, result)
+	if style == nil || err != nil {
+		return err
+	}
+
+	removed, ok := removeHeader(file, style, content, config)
+	if !ok {
+		result.Succeed(file)
+		return nil
+	}
+
+	return writeFix(file, content, removed, mode, config, result)
+}
+
+// Migrate rewrites the license headers of the from config to the ones of the to config, in a single pass of the
+// files in the paths of the to config. The files without the license header of the from config are checked against
+// the to config, so that the files that have neither of them are reported as failures.
+func Migrate(from, to *ConfigHeader, result *Result) []error {
+	fileList, err := ListFiles(to)
+	if err != nil {
+		return []error{err}
+	}
+
+	errs := forEachFile(fileList, to.Jobs, func(file string) error {
+		return MigrateFile(file, from, to, result)
+	})
+
+	result.Sort()
+
+	return errs
+}
+
+// MigrateFile rewrites the license header of the from config in the file to the one of the to config.
+func MigrateFile(file string, from, to *ConfigHeader, result *Result) error {
+	style, content, mode, err := readCommentable(file, to, result)
+	if style == nil || err != nil {
+		return err
+	}
+
+	removed, ok := removeHeader(file, style, content, from)
+	if !ok {
+		checkContent(file, content, to, result)
+		return nil
+	}
+
+	migrated, err := insertHeader(file, style, removed, to)
+	if err != nil {
+		return err
+	}
+
+	return writeFix(file, content, migrated, mode, to, result)
+}
+
+// readCommentable reads the file if it's not ignored by the config, and it's a text file that supports comments,
+// the returned comment style is nil if the file should be skipped.
+func readCommentable(file string, config *ConfigHeader, result *Result) (*comments.CommentStyle, []byte, os.FileMode, error) {
+	if yes, err := config.ShouldIgnore(file); yes || err != nil {
+		result.Ignore(file)
+		return nil, nil, 0, err
+	}
+
+	style := comments.FileCommentStyle(file)
+	if style == nil {
+		logger.Log.Debugln("Ignoring file without comment style:", file)
+		result.Ignore(file)
+		return nil, nil, 0, nil
+	}
+
+	stat, err := os.Stat(file)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if t := http.DetectContentType(content); !strings.HasPrefix(t, "text/") {
+		logger.Log.Debugln("Ignoring file:", file, "; type:", t)
+		result.Ignore(file)
+		return nil, nil, 0, nil
+	}
+
+	return style, content, stat.Mode(), nil
+}
+
+// removeHeader removes the license header described by the config from the content, it returns false if the header
+// is not found. The header is located by the pattern of the config (in the comment style of the file) if it's set,
+// by the license header generated from the config, or by identifying the leading comment block as the license.
+func removeHeader(file string, style *comments.CommentStyle, content []byte, config *ConfigHeader) ([]byte, bool) {
+	if pattern := config.LicensePattern(style); pattern != nil {
+		if loc := pattern.FindIndex(content); loc != nil {
+			return cut(content, loc[0], loc[1]), true
+		}
+	}
+
+	if config.License.Content == "" && config.License.SpdxID == "" {
+		return nil, false
+	}
+
+	if _, err := readLicenseFromSpdx(config); config.License.Content == "" && err != nil {
+		logger.Log.Debugln("No license header template to locate the license header:", err)
+	} else if generated, err := generateLicenseHeader(style, config.licenseContentOf(file)); err == nil {
+		generated = generated[:len(generated)-1] // the trailing blank line may be absent
+		if index := bytes.Index(content, []byte(generated)); index >= 0 {
+			return cut(content, index, index+len(generated)), true
+		}
+	}
+
+	start, end, ok := leadingComment(style, content)
+	if !ok {
+		return nil, false
+	}
+	found, err := lcs.Identify(string(content[start:end]), identifyThreshold)
+	if err != nil || !isLicense(found, config) {
+		return nil, false
+	}
+	logger.Log.Debugf("Removing the license header of %v in file: %v", found, file)
+
+	return cut(content, start, end), true
+}
+
+// cut removes the content between start and end, along with the blank lines after it.
+func cut(content []byte, start, end int) []byte {
+	rest := bytes.TrimLeft(content[end:], "\r\n")
+	removed := make([]byte, 0, start+len(rest))
+	removed = append(removed, content[:start]...)
+	return append(removed, rest...)
+}
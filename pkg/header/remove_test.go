@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveHeader(t *testing.T) {
+	style := comments.FileCommentStyle("main.go")
+	body := "// Package main is the entry.\npackage main\n"
+
+	apache := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"}}
+	require.NoError(t, apache.Finalize())
+	generated, err := GenerateLicenseHeader(style, apache)
+	require.NoError(t, err)
+
+	commented := func(id string) string {
+		content, err := lcs.GetLicenseContent(id)
+		require.NoError(t, err)
+		return "// " + strings.ReplaceAll(strings.TrimSpace(content), "\n", "\n// ") + "\n\n"
+	}
+
+	tests := []struct {
+		name    string
+		config  *ConfigHeader
+		content string
+		removed bool
+	}{
+		{"Generated", apache, generated + body, true},
+		{"Identified", &ConfigHeader{License: LicenseConfig{SpdxID: "MIT"}}, mitHeader + "\n" + body, true},
+		{"Pattern", &ConfigHeader{License: LicenseConfig{Pattern: `Copyright \(c\) \d{4} Someone`}}, "// Copyright (c) 2020 Someone\n\n" + body, true},
+		{"DifferentLicense", &ConfigHeader{License: LicenseConfig{SpdxID: "MIT"}}, generated + body, false},
+		{"SimilarID", &ConfigHeader{License: LicenseConfig{SpdxID: "MIT"}}, commented("MIT-0") + body, false},
+		{"SuffixedID", &ConfigHeader{License: LicenseConfig{SpdxID: "GPL-2.0-only"}}, commented("LGPL-2.0-only") + body, false},
+		{"OnlyID", &ConfigHeader{License: LicenseConfig{SpdxID: "LGPL-2.0-only"}}, commented("LGPL-2.0-only") + body, true},
+		{"NoHeader", &ConfigHeader{License: LicenseConfig{SpdxID: "MIT"}}, body, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			removed, ok := removeHeader("main.go", style, []byte(test.content), test.config)
+			require.Equal(t, test.removed, ok)
+			if ok {
+				require.Equal(t, body, string(removed))
+			}
+		})
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.go")
+
+	from := &ConfigHeader{License: LicenseConfig{SpdxID: "MIT", CopyrightOwner: "Someone", CopyrightYear: "2020"}}
+	to := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"}}
+	require.NoError(t, from.Finalize())
+	require.NoError(t, to.Finalize())
+
+	style := comments.FileCommentStyle(file)
+	old, err := GenerateLicenseHeader(style, from)
+	require.NoError(t, err)
+	expected, err := GenerateLicenseHeader(style, to)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(file, []byte(old+"package main\n"), 0o644))
+	result := &Result{}
+	require.NoError(t, MigrateFile(file, from, to, result))
+	require.Equal(t, []string{file}, result.Fixed)
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, expected+"package main\n", string(content))
+
+	// migrated files are valid and not migrated again
+	result = &Result{}
+	require.NoError(t, MigrateFile(file, from, to, result))
+	require.Equal(t, []string{file}, result.Success)
+	require.Empty(t, result.Fixed)
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/comments"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// leadingComment returns the offsets of the comment block at the start of the content, the content matched by
+// the After pattern of the style (such as the shebang) and the blank lines before the comment block are skipped.
+func leadingComment(style *comments.CommentStyle, content []byte) (start, end int, ok bool) {
+	if style.After != "" {
+		if loc := regexp.MustCompile(style.After).FindIndex(content); loc != nil && len(bytes.TrimSpace(content[:loc[0]])) == 0 {
+			start = loc[1]
+		}
+	}
+	for start < len(content) && isSpace(content[start]) {
+		start++
+	}
+	// the comment block must start at the beginning of a line
+	for start > 0 && content[start-1] != '\n' {
+		start--
+	}
+
+	rest := content[start:]
+	if style.Start == "" || !bytes.HasPrefix(bytes.TrimLeft(rest, " \t"), []byte(strings.TrimSpace(style.Start))) {
+		return 0, 0, false
+	}
+
+	if style.Start != style.Middle {
+		// block comments, such as /* ... */
+		startLen := bytes.Index(rest, []byte(strings.TrimSpace(style.Start))) + len(strings.TrimSpace(style.Start))
+		index := bytes.Index(rest[startLen:], []byte(strings.TrimSpace(style.End)))
+		if index < 0 {
+			return 0, 0, false
+		}
+		end = start + startLen + index + len(strings.TrimSpace(style.End))
+		if newline := bytes.IndexByte(content[end:], '\n'); newline >= 0 {
+			end += newline + 1
+		} else {
+			end = len(content)
+		}
+		return start, end, true
+	}
+
+	// line comments, such as // ...
+	end = start
+	middle := []byte(strings.TrimSpace(style.Middle))
+	for end < len(content) {
+		line := content[end:]
+		if newline := bytes.IndexByte(line, '\n'); newline >= 0 {
+			line = line[:newline+1]
+		}
+		if !bytes.HasPrefix(bytes.TrimLeft(line, " \t"), middle) {
+			break
+		}
+		end += len(line)
+	}
+	return start, end, true
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+// replaceHeader replaces the leading comment block of the content with the configured license header,
+// if the comment block is a license header and it's not protected. It returns false if nothing is replaced.
+func replaceHeader(file string, style *comments.CommentStyle, content []byte, config *ConfigHeader) ([]byte, bool, error) {
+	start, end, ok := leadingComment(style, content)
+	if !ok {
+		return nil, false, nil
+	}
+
+	block := string(content[start:end])
+	id, err := lcs.Identify(block, identifyThreshold)
+	if err != nil {
+		logger.Log.Debugln("The leading comment is not a license header:", file, err)
+		return nil, false, nil
+	}
+	if config.protects(id, block) {
+		logger.Log.Infoln("Keeping the protected license header of", id, "in file:", file)
+		return nil, false, nil
+	}
+
+	licenseHeader, err := generateLicenseHeader(style, config.licenseContentOf(file))
+	if err != nil {
+		return nil, false, err
+	}
+	logger.Log.Debugln("Replacing the license header of", id, "in file:", file)
+
+	rest := bytes.TrimLeft(content[end:], "\r\n")
+	replaced := make([]byte, 0, start+len(licenseHeader)+len(rest))
+	replaced = append(replaced, content[:start]...)
+	replaced = append(replaced, licenseHeader...)
+	replaced = append(replaced, rest...)
+	return replaced, true, nil
+}
+
+// protects returns whether the license header, identified as the given license, is protected from being replaced,
+// the protected headers are configured by SPDX IDs or regular expressions that match the normalized header.
+func (config *ConfigHeader) protects(id, header string) bool {
+	ids := strings.Split(id, " and ")
+	normalized := lcs.Normalize(header)
+	for _, protected := range config.ProtectedHeaders {
+		for _, id := range ids {
+			if strings.EqualFold(id, protected) {
+				return true
+			}
+		}
+		if r, err := regexp.Compile("(?i)" + protected); err == nil && r.MatchString(normalized) {
+			return true
+		}
+	}
+	return false
+}
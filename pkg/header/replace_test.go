@@ -0,0 +1,93 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadingComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected string
+	}{
+		{"LineComments", "main.go", "// line 1\n//\n// line 2\n\npackage main\n", "// line 1\n//\n// line 2\n"},
+		{"BlockComment", "Main.java", "\n/*\n * line 1\n */\npackage main;\n", "/*\n * line 1\n */\n"},
+		{"Shebang", "run.sh", "#!/bin/sh\n\n# line 1\n# line 2\necho\n", "# line 1\n# line 2\n"},
+		{"NoComment", "main.go", "package main\n// line 1\n", ""},
+		{"UnclosedBlock", "Main.java", "/*\n * line 1\n", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start, end, ok := leadingComment(comments.FileCommentStyle(test.file), []byte(test.content))
+			require.Equal(t, test.expected != "", ok)
+			if ok {
+				require.Equal(t, test.expected, test.content[start:end])
+			}
+		})
+	}
+}
+
+func TestReplaceHeader(t *testing.T) {
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"}}
+	require.NoError(t, c.Finalize())
+	c.Replace = true
+
+	style := comments.FileCommentStyle("main.go")
+	expected, err := GenerateLicenseHeader(style, c)
+	require.NoError(t, err)
+
+	body := "// Package main is the entry.\npackage main\n"
+	content := []byte(mitHeader + "\n" + body)
+
+	result := &Result{}
+	checkContent("main.go", content, c, result)
+	require.Equal(t, ReasonDifferentLicense, result.Diagnostic("main.go").Reason)
+
+	fixed, err := fixContent("main.go", content, ReasonDifferentLicense, c)
+	require.NoError(t, err)
+	require.Equal(t, expected+body, string(fixed))
+
+	// without the replace mode, the header is inserted before the existing one
+	c.Replace = false
+	fixed, err = fixContent("main.go", content, ReasonDifferentLicense, c)
+	require.NoError(t, err)
+	require.Equal(t, expected+string(content), string(fixed))
+
+	// the protected headers are kept
+	c.Replace = true
+	for _, protected := range []string{"MIT", `copyright .* someone`} {
+		c.ProtectedHeaders = []string{protected}
+		fixed, err = fixContent("main.go", content, ReasonDifferentLicense, c)
+		require.NoError(t, err)
+		require.True(t, strings.HasSuffix(string(fixed), string(content)), protected)
+	}
+
+	// the leading comments that are not license headers are kept
+	c.ProtectedHeaders = nil
+	fixed, err = fixContent("main.go", []byte(body), ReasonMissing, c)
+	require.NoError(t, err)
+	require.Equal(t, expected+body, string(fixed))
+}
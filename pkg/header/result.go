@@ -19,40 +19,99 @@ package header
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 )
 
+// Result collects the files checked (and fixed) by license-eye, it's safe to be appended concurrently.
 type Result struct {
 	Success []string
 	Failure []string
 	Ignored []string
 	Fixed   []string
+
+	// Diagnostics are the reasons why the files in Failure fail the check, keyed by the file path.
+	Diagnostics map[string]*Diagnostic
+
+	// Patches are the unified diffs of the files in Fixed, keyed by the file path, they're only recorded
+	// in the dry-run mode, where the files are not written.
+	Patches map[string]string
+
+	lock sync.Mutex
+}
+
+func (result *Result) Fail(diagnostic *Diagnostic) {
+	result.lock.Lock()
+	defer result.lock.Unlock()
+	result.Failure = append(result.Failure, diagnostic.File)
+	if result.Diagnostics == nil {
+		result.Diagnostics = make(map[string]*Diagnostic)
+	}
+	result.Diagnostics[diagnostic.File] = diagnostic
 }
 
-func (result *Result) Fail(file string) {
-	result.Failure = append(result.Failure, file)
+// Diagnostic returns the reason why the file fails the check.
+func (result *Result) Diagnostic(file string) *Diagnostic {
+	result.lock.Lock()
+	defer result.lock.Unlock()
+	if d, ok := result.Diagnostics[file]; ok {
+		return d
+	}
+	return &Diagnostic{File: file, Reason: ReasonMissing, Message: "the license header is missing or invalid", Offset: -1}
 }
 
 func (result *Result) Succeed(file string) {
+	result.lock.Lock()
+	defer result.lock.Unlock()
 	result.Success = append(result.Success, file)
 }
 
 func (result *Result) Ignore(file string) {
+	result.lock.Lock()
+	defer result.lock.Unlock()
 	result.Ignored = append(result.Ignored, file)
 }
 
 func (result *Result) Fix(file string) {
+	result.lock.Lock()
+	defer result.lock.Unlock()
 	result.Fixed = append(result.Fixed, file)
 }
 
+// Patch records that the file would be fixed with the patch, in the dry-run mode.
+func (result *Result) Patch(file, patch string) {
+	result.lock.Lock()
+	defer result.lock.Unlock()
+	result.Fixed = append(result.Fixed, file)
+	if result.Patches == nil {
+		result.Patches = make(map[string]string)
+	}
+	result.Patches[file] = patch
+}
+
+// Sort sorts all the file lists, so that the output doesn't depend on the order the files are processed in.
+func (result *Result) Sort() {
+	result.lock.Lock()
+	defer result.lock.Unlock()
+	sort.Strings(result.Success)
+	sort.Strings(result.Failure)
+	sort.Strings(result.Ignored)
+	sort.Strings(result.Fixed)
+}
+
 func (result *Result) HasFailure() bool {
 	return len(result.Failure) > 0
 }
 
 func (result *Result) Error() error {
+	failures := make([]string, 0, len(result.Failure))
+	for _, file := range result.Failure {
+		failures = append(failures, result.Diagnostic(file).String())
+	}
 	return fmt.Errorf(
 		"the following files don't have a valid license header: \n%v",
-		strings.Join(result.Failure, "\n"),
+		strings.Join(failures, "\n"),
 	)
 }
 
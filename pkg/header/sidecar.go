@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+)
+
+// SidecarSuffix is the suffix of the sidecar file, that carries the license header of the file it's named after.
+const SidecarSuffix = ".license"
+
+// usesSidecar returns whether the license header of the file is carried by its sidecar file, that is,
+// the sidecar files are enabled and the file is binary or its type doesn't support comments.
+func (config *ConfigHeader) usesSidecar(file string, content []byte) bool {
+	if !config.Sidecar {
+		return false
+	}
+	return !strings.HasPrefix(http.DetectContentType(content), "text/") || comments.FileCommentStyle(file) == nil
+}
+
+// isSidecar returns whether the file is the sidecar file of another file.
+func (config *ConfigHeader) isSidecar(file string) bool {
+	if !config.Sidecar || !strings.HasSuffix(file, SidecarSuffix) {
+		return false
+	}
+	stat, err := os.Stat(strings.TrimSuffix(file, SidecarSuffix))
+	return err == nil && stat.Mode().IsRegular()
+}
+
+// checkSidecar checks whether the sidecar file of the file contains the configured license header.
+func checkSidecar(file string, config *ConfigHeader, result *Result) {
+	sidecar := file + SidecarSuffix
+	content, err := os.ReadFile(sidecar)
+	if err != nil {
+		result.Fail(&Diagnostic{
+			File:    file,
+			Reason:  ReasonMissing,
+			Message: fmt.Sprintf("the sidecar file %v is missing", sidecar),
+			Offset:  -1,
+		})
+		return
+	}
+
+	var r Result
+	checkText(file, content, config, &r)
+	if r.HasFailure() {
+		d := r.Diagnostic(file)
+		d.Message = fmt.Sprintf("%v: %v", sidecar, d.Message)
+		result.Fail(d)
+	} else {
+		result.Succeed(file)
+	}
+}
+
+// fixSidecar creates the sidecar file of the file with the configured license header,
+// or fixes the license header in the existing one.
+func fixSidecar(file string, reason Reason, config *ConfigHeader, result *Result) error {
+	sidecar := file + SidecarSuffix
+
+	content, err := os.ReadFile(sidecar)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	fixed, err := fixSidecarContent(file, content, reason, config)
+	if err != nil {
+		return err
+	}
+
+	return writeFix(sidecar, content, fixed, 0o644, config, result)
+}
+
+// fixSidecarContent returns the content of the sidecar file with the license header fixed, the header is written as
+// plain text, as the sidecar files have no comments.
+func fixSidecarContent(file string, content []byte, reason Reason, config *ConfigHeader) ([]byte, error) {
+	if config.License.Style == LicenseStyleSPDXShort {
+		if fixed, ok := rewriteShortTags(file, content, config); ok {
+			return fixed, nil
+		}
+	} else if reason == ReasonCopyrightYear {
+		if fixed, ok := rewriteCopyrightYear(file, content, config); ok {
+			return fixed, nil
+		}
+	}
+
+	licenseHeader := strings.TrimRight(config.licenseContentOf(file), "\n") + "\n"
+	if config.Replace || len(strings.TrimSpace(string(content))) == 0 {
+		return []byte(licenseHeader), nil
+	}
+	return []byte(licenseHeader + "\n" + string(content)), nil
+}
@@ -0,0 +1,91 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSidecar(t *testing.T) {
+	dir := t.TempDir()
+	image, data := filepath.Join(dir, "logo.png"), filepath.Join(dir, "data.json")
+	require.NoError(t, os.WriteFile(image, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), 0o644))
+	require.NoError(t, os.WriteFile(data, []byte("{}\n"), 0o644))
+
+	config := &ConfigHeader{
+		License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"},
+		Paths:   []string{"**"},
+		Sidecar: true,
+	}
+	require.NoError(t, config.Finalize())
+
+	result := &Result{}
+	for _, file := range []string{image, data} {
+		require.NoError(t, CheckFile(file, config, result))
+	}
+	require.Len(t, result.Failure, 2)
+	require.Equal(t, ReasonMissing, result.Diagnostic(image).Reason)
+	require.Contains(t, result.Diagnostic(image).Message, image+SidecarSuffix)
+
+	result = &Result{}
+	require.Empty(t, FixFiles([]string{image, data}, config, result))
+	require.ElementsMatch(t, []string{image + SidecarSuffix, data + SidecarSuffix}, result.Fixed)
+
+	sidecar, err := os.ReadFile(image + SidecarSuffix)
+	require.NoError(t, err)
+	require.Contains(t, string(sidecar), "Copyright 2024 Acme")
+	require.NotContains(t, string(sidecar), "*")
+
+	result = &Result{}
+	for _, file := range []string{image, data, image + SidecarSuffix} {
+		require.NoError(t, CheckFile(file, config, result))
+	}
+	require.Empty(t, result.Failure)
+	require.Equal(t, []string{image + SidecarSuffix}, result.Ignored)
+
+	// the sidecar of another license is fixed in place
+	require.NoError(t, os.WriteFile(data+SidecarSuffix, []byte("Copyright 2024 Someone\n"), 0o644))
+	result = &Result{}
+	require.NoError(t, Fix(data, config, result))
+	sidecar, err = os.ReadFile(data + SidecarSuffix)
+	require.NoError(t, err)
+	require.Contains(t, string(sidecar), "Apache License")
+	require.Contains(t, string(sidecar), "Copyright 2024 Someone")
+}
+
+func TestSidecarDisabled(t *testing.T) {
+	image := filepath.Join(t.TempDir(), "logo.png")
+	require.NoError(t, os.WriteFile(image, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), 0o644))
+
+	config := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0"}, Paths: []string{"**"}}
+	require.NoError(t, config.Finalize())
+
+	result := &Result{}
+	require.NoError(t, CheckFile(image, config, result))
+	require.Empty(t, result.Failure)
+	require.Empty(t, result.Success)
+}
+
+func TestSidecarPatch(t *testing.T) {
+	patch := unifiedDiff("logo.png.license", nil, []byte("Copyright 2024 Acme\n"))
+	require.Equal(t, "--- /dev/null\n+++ b/logo.png.license\n@@ -0,0 +1 @@\n+Copyright 2024 Acme\n", patch)
+}
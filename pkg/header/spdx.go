@@ -0,0 +1,205 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+// LicenseStyle is the style of the license header.
+type LicenseStyle string
+
+const (
+	// LicenseStyleFull is the full text of the license header, the default style.
+	LicenseStyleFull LicenseStyle = ""
+	// LicenseStyleSPDXShort is the REUSE-style short form, that is, the SPDX-FileCopyrightText and
+	// SPDX-License-Identifier tags instead of the license text.
+	LicenseStyleSPDXShort LicenseStyle = "spdx-short"
+)
+
+const (
+	copyrightTag  = "SPDX-FileCopyrightText:"
+	identifierTag = "SPDX-License-Identifier:"
+)
+
+var (
+	// the value of a tag ends at the end of the line, or the end of a one-line comment, such as /* ... */
+	identifierLine = regexp.MustCompile(`(?m)^(.*?)` + regexp.QuoteMeta(identifierTag) + `[ \t]*(.*?)[ \t]*(?:\*/|-->|-\}|#\}|\*\}|\*\))?[ \t]*\r?$`)
+	copyrightLine  = regexp.MustCompile(`(?m)^(.*?)` + regexp.QuoteMeta(copyrightTag) + `[ \t]*(.*?)[ \t]*(?:\*/|-->|-\}|#\}|\*\}|\*\))?[ \t]*\r?$`)
+	copyrightText  = regexp.MustCompile(`(?i)^(?:(?:copyright|\(c\)|©)\s*)*(\d{4}(?:\s*[-,]\s*\d{4})*)?\s*(.*)$`)
+)
+
+func (style LicenseStyle) validate(config *ConfigHeader) error {
+	switch style {
+	case LicenseStyleFull:
+		return nil
+	case LicenseStyleSPDXShort:
+		if config.License.SpdxID == "" {
+			return fmt.Errorf("spdx-id is required by the license style %v", style)
+		}
+		_, err := lcs.ParseExpression(config.License.SpdxID)
+		return err
+	}
+	return fmt.Errorf("unknown license style %q, expected %q", style, LicenseStyleSPDXShort)
+}
+
+// shortLicenseContent returns the SPDX tags of the license header in the spdx-short style.
+func (config *ConfigHeader) shortLicenseContent(year, owner string) string {
+	content := identifierTag + " " + config.License.SpdxID + "\n"
+	if owner != "" {
+		content = copyrightTag + " " + strings.TrimSpace(year+" "+owner) + "\n" + content
+	}
+	return content
+}
+
+// diagnoseShort checks the SPDX tags in the head of the file, it returns nil if they satisfy the config.
+func diagnoseShort(file, raw string, config *ConfigHeader) *Diagnostic {
+	head := raw
+	if len(head) > identifyHeadBytes {
+		head = head[:identifyHeadBytes]
+	}
+	d := &Diagnostic{File: file, Offset: -1}
+
+	identifier := identifierLine.FindStringSubmatchIndex(head)
+	if identifier == nil {
+		d.Reason = ReasonMissing
+		d.Message = "the " + identifierTag + " tag is missing"
+		return d
+	}
+	d.Offset = identifier[0]
+	d.Line = strings.Count(head[:identifier[0]], "\n") + 1
+
+	expression := head[identifier[4]:identifier[5]]
+	found, err := lcs.ParseExpression(expression)
+	if err != nil {
+		d.Reason = ReasonInvalidExpression
+		d.Message = err.Error()
+		return d
+	}
+	if expected, _ := lcs.ParseExpression(config.License.SpdxID); found != expected {
+		d.Reason = ReasonDifferentLicense
+		d.Message = fmt.Sprintf("the %s is %q, expected %q", identifierTag, expression, config.License.SpdxID)
+		return d
+	}
+
+	owner := strings.TrimSpace(config.License.CopyrightOwner)
+	if owner == "" {
+		return nil
+	}
+
+	copyrights := copyrightLine.FindAllStringSubmatchIndex(head, -1)
+	if len(copyrights) == 0 {
+		d.Reason = ReasonMissing
+		d.Message = "the " + copyrightTag + " tag is missing"
+		return d
+	}
+	var owners []string
+	for _, loc := range copyrights {
+		year, name := parseCopyrightText(head[loc[4]:loc[5]])
+		if name != owner {
+			owners = append(owners, name)
+			continue
+		}
+		d.Offset = loc[0]
+		d.Line = strings.Count(head[:loc[0]], "\n") + 1
+		switch {
+		case year == "" && config.License.CopyrightYearPolicy == YearPolicyAny:
+			return nil
+		case year == "":
+			d.Reason = ReasonCopyrightYear
+			d.Message = fmt.Sprintf("the copyright year is missing, expected %q", config.copyrightYearOf(file, ""))
+			return d
+		case !config.acceptsYear(file, lcs.Normalize(year)):
+			d.Reason = ReasonCopyrightYear
+			d.Message = fmt.Sprintf("the copyright year is %q, expected %q", year, config.copyrightYearOf(file, year))
+			return d
+		}
+		return nil
+	}
+
+	d.Reason = ReasonCopyrightOwner
+	d.Message = fmt.Sprintf("the copyright owner is %q, expected %q", strings.Join(owners, `", "`), owner)
+	return d
+}
+
+// parseCopyrightText parses the value of the SPDX-FileCopyrightText tag into the year and the owner.
+func parseCopyrightText(text string) (year, owner string) {
+	m := copyrightText.FindStringSubmatch(strings.TrimSpace(text))
+	return m[1], strings.TrimSpace(m[2])
+}
+
+// rewriteShortTags rewrites the values of the existing SPDX tags in the head of the content to the configured ones,
+// and adds the SPDX-FileCopyrightText tag of the configured owner if it's missing, leaving the rest of the content,
+// including the SPDX-FileCopyrightText tags of the other owners, untouched. It returns
+// false if the SPDX-License-Identifier tag is not found, so the whole license header needs to be inserted.
+func rewriteShortTags(file string, content []byte, config *ConfigHeader) ([]byte, bool) {
+	head := string(content)
+	if len(head) > identifyHeadBytes {
+		head = head[:identifyHeadBytes]
+	}
+
+	identifier := identifierLine.FindStringSubmatchIndex(head)
+	if identifier == nil {
+		return nil, false
+	}
+
+	type replacement struct {
+		start, end int
+		text       string
+	}
+	replacements := []replacement{{identifier[4], identifier[5], config.License.SpdxID}}
+
+	if owner := strings.TrimSpace(config.License.CopyrightOwner); owner != "" {
+		copyrights := copyrightLine.FindAllStringSubmatchIndex(head, -1)
+		target := -1
+		for i, loc := range copyrights {
+			if _, name := parseCopyrightText(head[loc[4]:loc[5]]); name == owner {
+				target = i
+				break
+			}
+		}
+		if target >= 0 {
+			loc := copyrights[target]
+			year, _ := parseCopyrightText(head[loc[4]:loc[5]])
+			text := strings.TrimSpace(config.copyrightYearOf(file, year) + " " + owner)
+			replacements = append(replacements, replacement{loc[4], loc[5], text})
+		} else {
+			// add the tag before the identifier, with the same comment prefix, the tags of the other owners are kept
+			prefix := head[identifier[2]:identifier[3]]
+			text := prefix + copyrightTag + " " + strings.TrimSpace(config.copyrightYearOf(file, "")+" "+owner) + "\n"
+			replacements = append(replacements, replacement{identifier[0], identifier[0], text})
+		}
+	}
+
+	// apply the replacements from the end, so that the offsets of the others are not affected
+	if len(replacements) == 2 && replacements[1].start > replacements[0].start {
+		replacements[0], replacements[1] = replacements[1], replacements[0]
+	}
+	rewritten := string(content)
+	for _, r := range replacements {
+		rewritten = rewritten[:r.start] + r.text + rewritten[r.end:]
+	}
+	if rewritten == string(content) {
+		return nil, false
+	}
+	return []byte(rewritten), true
+}
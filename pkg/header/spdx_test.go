@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPDXShortStyle(t *testing.T) {
+	c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024", Style: LicenseStyleSPDXShort}}
+	require.NoError(t, c.Finalize())
+
+	header, err := GenerateLicenseHeader(comments.FileCommentStyle("Main.java"), c)
+	require.NoError(t, err)
+	require.Equal(t, "/*\n * SPDX-FileCopyrightText: 2024 Acme\n * SPDX-License-Identifier: Apache-2.0\n */\n\n", header)
+
+	tests := []struct {
+		name    string
+		content string
+		reason  Reason
+	}{
+		{"Valid", "// SPDX-FileCopyrightText: 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n", ""},
+		{"OneLineComment", "/* SPDX-License-Identifier: apache-2.0 */\n/* SPDX-FileCopyrightText: Copyright 2024 Acme */\n", ""},
+		{"Missing", "package main\n", ReasonMissing},
+		{"MissingCopyright", "// SPDX-License-Identifier: Apache-2.0\n", ReasonMissing},
+		{"InvalidExpression", "// SPDX-License-Identifier: Apache-2.0 OR\n", ReasonInvalidExpression},
+		{"DifferentLicense", "// SPDX-FileCopyrightText: 2024 Acme\n// SPDX-License-Identifier: MIT\n", ReasonDifferentLicense},
+		{"CopyrightYear", "// SPDX-FileCopyrightText: 2020 Acme\n// SPDX-License-Identifier: Apache-2.0\n", ReasonCopyrightYear},
+		{"CopyrightOwner", "// SPDX-FileCopyrightText: 2024 Someone\n// SPDX-License-Identifier: Apache-2.0\n", ReasonCopyrightOwner},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content := []byte(test.content + "package main\n")
+			result := &Result{}
+			checkContent("main.go", content, c, result)
+			if test.reason == "" {
+				require.Equal(t, []string{"main.go"}, result.Success)
+				return
+			}
+			require.Equal(t, test.reason, result.Diagnostic("main.go").Reason, result.Diagnostic("main.go").Message)
+
+			fixed, err := fixContent("main.go", content, test.reason, c)
+			require.NoError(t, err)
+			result = &Result{}
+			checkContent("main.go", fixed, c, result)
+			require.Equal(t, []string{"main.go"}, result.Success, string(fixed))
+		})
+	}
+
+	fixed, ok := rewriteShortTags("main.go", []byte("// SPDX-License-Identifier: MIT\npackage main\n"), c)
+	require.True(t, ok)
+	require.Equal(t, "// SPDX-FileCopyrightText: 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\npackage main\n", string(fixed))
+
+	// the copyright of another owner is kept
+	fixed, ok = rewriteShortTags("main.go", []byte("// SPDX-FileCopyrightText: 2019 Someone\n// SPDX-License-Identifier: MIT\n"), c)
+	require.True(t, ok)
+	require.Equal(t, "// SPDX-FileCopyrightText: 2019 Someone\n// SPDX-FileCopyrightText: 2024 Acme\n// SPDX-License-Identifier: Apache-2.0\n", string(fixed))
+
+	c.License.CopyrightYear, c.License.CopyrightYearPolicy = "", YearPolicyRangeToCurrent
+	fixed, ok = rewriteShortTags("main.go", []byte("// SPDX-FileCopyrightText: 2019 Acme\n// SPDX-License-Identifier: Apache-2.0\n"), c)
+	require.True(t, ok)
+	require.Equal(t, fmt.Sprintf("// SPDX-FileCopyrightText: 2019-%d Acme\n// SPDX-License-Identifier: Apache-2.0\n", time.Now().Year()), string(fixed))
+
+	require.Error(t, (&ConfigHeader{License: LicenseConfig{Style: LicenseStyleSPDXShort}}).Finalize())
+	require.Error(t, (&ConfigHeader{License: LicenseConfig{SpdxID: "Unknown-1.0", Style: LicenseStyleSPDXShort}}).Finalize())
+	require.Error(t, (&ConfigHeader{License: LicenseConfig{SpdxID: "MIT", Style: "unknown"}}).Finalize())
+}
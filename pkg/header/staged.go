@@ -0,0 +1,349 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// stagedIndex is the git index of the repository in the current directory,
+// the object database of the repository is not safe for concurrent use, so
+// all the accesses to the blobs are guarded by the lock.
+type stagedIndex struct {
+	repo *git.Repository
+	// file is the index file, which is $GIT_INDEX_FILE in the git hooks, such as
+	// .git/index.lock in `git commit -a`, or .git/index otherwise.
+	file    string
+	index   *index.Index
+	entries map[string]*index.Entry
+	files   []string
+	lock    sync.Mutex
+}
+
+func openStagedIndex() (*stagedIndex, error) {
+	repo, err := git.PlainOpen("./")
+	if err != nil {
+		return nil, fmt.Errorf("checking the staged files requires a git repository: %w", err)
+	}
+
+	file, err := indexFile(repo)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := readIndex(file)
+	if err != nil {
+		return nil, err
+	}
+	head, err := headTree(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &stagedIndex{repo: repo, file: file, index: idx, entries: make(map[string]*index.Entry)}
+	for _, entry := range idx.Entries {
+		if entry.Stage != 0 { // index.Merged is wrongly defined as 1 in go-git
+			continue // the file has unresolved conflicts
+		}
+		if entry.Mode == filemode.Submodule {
+			continue
+		}
+		if head != nil {
+			if committed, err := head.FindEntry(entry.Name); err == nil && committed.Hash == entry.Hash {
+				continue // not changed since HEAD
+			}
+		}
+		s.entries[entry.Name] = entry
+		s.files = append(s.files, entry.Name)
+	}
+
+	return s, nil
+}
+
+// indexFile returns the path of the index file, respecting $GIT_INDEX_FILE, which git sets
+// for the hooks when it commits from another index than .git/index.
+func indexFile(repo *git.Repository) (string, error) {
+	if file := os.Getenv("GIT_INDEX_FILE"); file != "" {
+		return file, nil
+	}
+
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", fmt.Errorf("unsupported git repository storage")
+	}
+
+	return filepath.Join(storage.Filesystem().Root(), "index"), nil
+}
+
+func readIndex(file string) (*index.Index, error) {
+	idx := &index.Index{Version: 2}
+
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return idx, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := index.NewDecoder(bufio.NewReader(f)).Decode(idx); err != nil {
+		return nil, fmt.Errorf("failed to read the git index %v: %w", file, err)
+	}
+
+	return idx, nil
+}
+
+// headTree returns the tree of the HEAD commit, or nil if there is no commit yet.
+func headTree(repo *git.Repository) (*object.Tree, error) {
+	ref, err := repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	return commit.Tree()
+}
+
+// content returns the staged content of the file.
+func (s *stagedIndex) content(file string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	blob, err := s.repo.BlobObject(s.entries[file].Hash)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// stage writes the content as a blob and stages it as the content of the file.
+func (s *stagedIndex) stage(file string, content []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	obj := s.repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	writer, err := obj.Writer()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	hash, err := s.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return err
+	}
+
+	entry, ok := s.entries[file]
+	if !ok { // the file is newly added, e.g. a sidecar file
+		entry = s.index.Add(file)
+		entry.Mode = filemode.Regular
+		s.entries[file] = entry
+	}
+	entry.Hash = hash
+	entry.Size = uint32(len(content))
+	// reset the cached stat data so that git compares the working tree file with the new blob
+	entry.CreatedAt, entry.ModifiedAt = time.Time{}, time.Time{}
+
+	return nil
+}
+
+// save writes the index into the index file, through a lock file like git does.
+func (s *stagedIndex) save() (err error) {
+	lock := s.file + ".lock"
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to lock the git index %v: %w", s.file, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(lock)
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	if err := index.NewEncoder(w).Encode(s.index); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(lock, s.file)
+}
+
+// CheckStaged checks the license headers of the files staged in the git index,
+// the staged contents are checked instead of the contents in the working tree.
+func CheckStaged(config *ConfigHeader, result *Result) error {
+	staged, err := openStagedIndex()
+	if err != nil {
+		return err
+	}
+
+	errs := forEachFile(staged.files, config.Jobs, func(file string) error {
+		return checkStagedFile(staged, file, config, result)
+	})
+
+	result.Sort()
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
+
+func checkStagedFile(staged *stagedIndex, file string, config *ConfigHeader, result *Result) error {
+	if yes, err := config.ShouldIgnore(file); yes || err != nil {
+		result.Ignore(file)
+		return err
+	}
+
+	logger.Log.Debugln("Checking staged file:", file)
+
+	content, err := staged.content(file)
+	if err != nil {
+		return err
+	}
+
+	checkContent(file, content, config, result)
+
+	return nil
+}
+
+// FixStaged adds the configured license header to the staged contents of the files
+// that are lack of it, and stages the fixed contents. The files in the working tree
+// are fixed as well, so that the fixes are not shown as unstaged changes.
+func FixStaged(config *ConfigHeader, result *Result) []error {
+	staged, err := openStagedIndex()
+	if err != nil {
+		return []error{err}
+	}
+
+	errs := forEachFile(staged.files, config.Jobs, func(file string) error {
+		return fixStagedFile(staged, file, config, result)
+	})
+
+	if !config.DryRun {
+		if err := staged.save(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	result.Sort()
+
+	return errs
+}
+
+func fixStagedFile(staged *stagedIndex, file string, config *ConfigHeader, result *Result) error {
+	var r Result
+	if err := checkStagedFile(staged, file, config, &r); err != nil {
+		return err
+	}
+	switch {
+	case len(r.Ignored) > 0:
+		result.Ignore(file)
+		return nil
+	case !r.HasFailure():
+		result.Succeed(file)
+		return nil
+	}
+	result.Fail(r.Diagnostic(file))
+
+	content, err := staged.content(file)
+	if err != nil {
+		return err
+	}
+	if config.usesSidecar(file, content) {
+		return fixStagedSidecar(staged, file, r.Diagnostic(file).Reason, config, result)
+	}
+	fixed, err := fixContent(file, content, r.Diagnostic(file).Reason, config)
+	if err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		result.Patch(file, unifiedDiff(file, content, fixed))
+		return nil
+	}
+	if err := staged.stage(file, fixed); err != nil {
+		return err
+	}
+
+	var w Result
+	if err := CheckFile(file, config, &w); err == nil && w.HasFailure() {
+		if err := Fix(file, config, &Result{}); err != nil {
+			return err
+		}
+	} else if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	result.Fix(file)
+
+	return nil
+}
+
+// fixStagedSidecar fixes the sidecar file of the staged file in the working tree, and stages it.
+func fixStagedSidecar(staged *stagedIndex, file string, reason Reason, config *ConfigHeader, result *Result) error {
+	if err := fixSidecar(file, reason, config, result); err != nil || config.DryRun {
+		return err
+	}
+
+	sidecar := file + SidecarSuffix
+	content, err := os.ReadFile(sidecar)
+	if err != nil {
+		return err
+	}
+	return staged.stage(sidecar, content)
+}
@@ -0,0 +1,167 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaged(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	c := &ConfigHeader{
+		License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"},
+		Paths:   []string{"**"},
+	}
+	require.NoError(t, c.Finalize())
+	header, err := GenerateLicenseHeader(comments.FileCommentStyle("main.go"), c)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile("committed.go", []byte(header+"package main\n"), 0o644))
+	_, err = worktree.Add("committed.go")
+	require.NoError(t, err)
+	_, err = worktree.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com"},
+	})
+	require.NoError(t, err)
+
+	// staged without the header, but the header is added in the working tree afterwards
+	require.NoError(t, os.WriteFile("partial.go", []byte("package main\n"), 0o644))
+	_, err = worktree.Add("partial.go")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile("partial.go", []byte(header+"package main\n"), 0o644))
+
+	// staged without the header, same as the working tree
+	require.NoError(t, os.WriteFile("full.go", []byte("package main\n"), 0o644))
+	_, err = worktree.Add("full.go")
+	require.NoError(t, err)
+
+	// not staged, never checked
+	require.NoError(t, os.WriteFile("untracked.go", []byte("package main\n"), 0o644))
+
+	result := &Result{}
+	require.NoError(t, CheckStaged(c, result))
+	require.Equal(t, []string{"full.go", "partial.go"}, result.Failure)
+	require.Empty(t, result.Success)
+
+	result = &Result{}
+	require.Empty(t, FixStaged(c, result))
+	require.Equal(t, []string{"full.go", "partial.go"}, result.Fixed)
+
+	result = &Result{}
+	require.NoError(t, CheckStaged(c, result))
+	require.Equal(t, []string{"full.go", "partial.go"}, result.Success)
+	require.False(t, result.HasFailure())
+
+	idx, err := repo.Storer.Index()
+	require.NoError(t, err)
+	for _, file := range []string{"full.go", "partial.go"} {
+		entry, err := idx.Entry(file)
+		require.NoError(t, err)
+		blob, err := repo.BlobObject(entry.Hash)
+		require.NoError(t, err)
+		reader, err := blob.Reader()
+		require.NoError(t, err)
+		staged, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, string(content), string(staged))
+		require.Equal(t, 1, strings.Count(string(content), "http://www.apache.org/licenses/LICENSE-2.0"), file)
+	}
+}
+
+func stagedHookConfig(t *testing.T) *ConfigHeader {
+	c := &ConfigHeader{
+		License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2024"},
+		Paths:   []string{"**"},
+	}
+	require.NoError(t, c.Finalize())
+	return c
+}
+
+// TestStagedHook is run by the pre-commit hook installed by TestStagedCommitAll, as `header fix --staged`.
+func TestStagedHook(t *testing.T) {
+	if os.Getenv("LICENSE_EYE_TEST_HOOK") == "" {
+		t.Skip("only run by the pre-commit hook")
+	}
+	result := &Result{}
+	require.Empty(t, FixStaged(stagedHookConfig(t), result))
+	require.Equal(t, []string{"f.go"}, result.Fixed)
+	require.Equal(t, "Totally checked 1 files, valid: 0, invalid: 1, ignored: 0, fixed: 1", result.String())
+}
+
+func TestStagedCommitAll(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_NOSYSTEM=1", "GIT_CONFIG_GLOBAL="+os.DevNull)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return string(output)
+	}
+
+	run("init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "f.go"), []byte("package main\n"), 0o644))
+	run("add", "f.go")
+	run("commit", "-q", "--no-verify", "-m", "initial commit")
+
+	hook := fmt.Sprintf("#!/bin/sh\nexec env LICENSE_EYE_TEST_HOOK=1 '%s' -test.run '^TestStagedHook$'\n", executable)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "hooks", "pre-commit"), []byte(hook), 0o755)) //nolint:gosec // hooks must be executable
+
+	// modified in the working tree only, `git commit -a` stages it into .git/index.lock before running the hook
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "f.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+	run("commit", "-q", "-a", "-m", "commit all")
+
+	committed := run("show", "HEAD:f.go")
+	require.Equal(t, 1, strings.Count(committed, "http://www.apache.org/licenses/LICENSE-2.0"), committed)
+	require.Contains(t, committed, "func main() {}")
+	require.Empty(t, run("status", "--porcelain"))
+}
@@ -0,0 +1,397 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CopyrightYearPolicy decides which copyright year is valid in the license header of a file.
+type CopyrightYearPolicy string
+
+const (
+	// YearPolicyConfigured requires the configured copyright-year, or the current year if it's not configured.
+	YearPolicyConfigured CopyrightYearPolicy = ""
+	// YearPolicyAny accepts any year or year range.
+	YearPolicyAny CopyrightYearPolicy = "any"
+	// YearPolicyCreationYear requires the year when the file is added into the git history.
+	YearPolicyCreationYear CopyrightYearPolicy = "creation-year"
+	// YearPolicyRangeToCurrent requires a year range from the creation year to the current year, e.g. 2019-2026.
+	YearPolicyRangeToCurrent CopyrightYearPolicy = "range-to-current"
+	// YearPolicyLastModified requires the year when the file is modified the last time.
+	YearPolicyLastModified CopyrightYearPolicy = "last-modified"
+)
+
+// CopyrightYearPolicies are all the supported copyright year policies.
+var CopyrightYearPolicies = []CopyrightYearPolicy{
+	YearPolicyAny, YearPolicyCreationYear, YearPolicyRangeToCurrent, YearPolicyLastModified,
+}
+
+var (
+	// years matches a year, a year range, or a list of them, in the license header.
+	years     = regexp.MustCompile(`^\d{4}(?:\s*[-,]\s*\d{4})*$`)
+	yearRange = regexp.MustCompile(`^(\d{4})(?:\s*-\s*(\d{4}))?$`)
+)
+
+func (policy CopyrightYearPolicy) validate() error {
+	if policy == YearPolicyConfigured {
+		return nil
+	}
+	for _, p := range CopyrightYearPolicies {
+		if p == policy {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown copyright-year-policy %q, expected one of %v", policy, CopyrightYearPolicies)
+}
+
+// needsHistory returns whether the policy requires the git history of the files.
+func (policy CopyrightYearPolicy) needsHistory() bool {
+	return policy == YearPolicyCreationYear || policy == YearPolicyRangeToCurrent || policy == YearPolicyLastModified
+}
+
+// fileYears is the years when the files are created and modified the last time, according to the git history.
+// The history is walked lazily along the first parents, from the HEAD back to the commits where the files are
+// added, so the walk stops as soon as the years of all the checked files are found.
+type fileYears struct {
+	mu     sync.Mutex
+	opened bool
+	// next is the next commit to walk, nil if the history is exhausted.
+	next    *object.Commit
+	created map[string]int
+	updated map[string]int
+	// dirty files are the files that are modified but not committed yet, and the added ones are not in the history.
+	dirty map[string]bool
+	added map[string]bool
+}
+
+func (y *fileYears) open() {
+	if y.opened {
+		return
+	}
+	y.opened = true
+	y.created, y.updated = make(map[string]int), make(map[string]int)
+	y.dirty, y.added = make(map[string]bool), make(map[string]bool)
+
+	repo, err := git.PlainOpen("./")
+	if err != nil {
+		logger.Log.Debugln("Not a git repository, the current year is used as the copyright year:", err)
+		return
+	}
+
+	if t, err := repo.Worktree(); err == nil {
+		if status, err := t.Status(); err == nil {
+			for file, s := range status {
+				if s.Worktree != git.Unmodified || s.Staging != git.Unmodified {
+					y.dirty[file] = true
+				}
+				if s.Worktree == git.Untracked || s.Staging == git.Added {
+					y.added[file] = true
+				}
+			}
+		}
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		logger.Log.Debugln("Repository has no commits, the current year is used as the copyright year:", err)
+		return
+	}
+	if y.next, err = repo.CommitObject(head.Hash()); err != nil {
+		logger.Log.Warnln("Failed to read the copyright years from the git history:", err)
+	}
+}
+
+// step walks the next commit, comparing it with its first parent, the changes of the merge commits are the ones
+// merged into the first parent.
+func (y *fileYears) step() error {
+	commit := y.next
+	y.next = nil
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	var parent *object.Commit
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		if parent, err = commit.Parent(0); err != nil {
+			return err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return err
+	}
+
+	year := commit.Author.When.Year()
+	for _, change := range changes {
+		file := change.To.Name
+		if file == "" {
+			continue
+		}
+		// the history is walked backwards, so the first change of the file is the last modification
+		if _, ok := y.updated[file]; !ok {
+			y.updated[file] = year
+		}
+		if _, ok := y.created[file]; !ok && change.From.Name == "" {
+			y.created[file] = year
+		}
+	}
+	y.next = parent
+	return nil
+}
+
+// yearOf returns the year when the file is created or modified the last time, walking the history until it's found.
+func (y *fileYears) yearOf(file string, created bool) (int, bool) {
+	if y == nil {
+		return 0, false
+	}
+	y.mu.Lock()
+	defer y.mu.Unlock()
+
+	y.open()
+	file = filepath.ToSlash(filepath.Clean(file))
+	if y.added[file] || (!created && y.dirty[file]) {
+		return 0, false
+	}
+	years := y.updated
+	if created {
+		years = y.created
+	}
+	for {
+		if year, ok := years[file]; ok {
+			return year, true
+		}
+		if y.next == nil {
+			return 0, false
+		}
+		if err := y.step(); err != nil {
+			logger.Log.Warnln("Failed to read the copyright years from the git history:", err)
+		}
+	}
+}
+
+func (y *fileYears) createdYear(file string) (int, bool) {
+	return y.yearOf(file, true)
+}
+
+func (y *fileYears) updatedYear(file string) (int, bool) {
+	return y.yearOf(file, false)
+}
+
+// copyrightYearOf returns the copyright year that should be in the license header of the file,
+// found is the copyright year that is already in the header, if any.
+func (config *ConfigHeader) copyrightYearOf(file, found string) string {
+	current := time.Now().Year()
+
+	switch config.License.CopyrightYearPolicy {
+	case YearPolicyCreationYear:
+		if year, ok := config.years.createdYear(file); ok {
+			return strconv.Itoa(year)
+		}
+		return strconv.Itoa(current)
+	case YearPolicyRangeToCurrent:
+		start := current
+		if year, err := strconv.Atoi(config.License.CopyrightYear); err == nil {
+			start = year
+		} else if year, ok := config.years.createdYear(file); ok {
+			start = year
+		}
+		// keep the start year of the existing header if it's earlier, e.g. the file is moved from elsewhere
+		if m := yearRange.FindStringSubmatch(strings.TrimSpace(found)); m != nil {
+			if year, _ := strconv.Atoi(m[1]); year < start {
+				start = year
+			}
+		}
+		if start >= current {
+			return strconv.Itoa(current)
+		}
+		return fmt.Sprintf("%d-%d", start, current)
+	case YearPolicyLastModified:
+		if year, ok := config.years.updatedYear(file); ok {
+			return strconv.Itoa(year)
+		}
+		return strconv.Itoa(current)
+	default:
+		return config.copyrightYear()
+	}
+}
+
+// acceptsYear returns whether the copyright year found in the license header of the file is valid.
+func (config *ConfigHeader) acceptsYear(file, found string) bool {
+	found = strings.TrimSpace(found)
+	if config.License.CopyrightYearPolicy == YearPolicyAny {
+		return years.MatchString(found)
+	}
+	return found == lcs.Normalize(config.copyrightYearOf(file, found))
+}
+
+// copyrightMatch is the license header found in a normalized content, ignoring the copyright year and owner.
+type copyrightMatch struct {
+	// Offset is the offset of the header in the normalized content.
+	Offset int
+	// Year and Owner are the normalized copyright year and owner in the header, empty if not in the license.
+	Year, Owner string
+}
+
+// matchCopyright finds the license header in the normalized content, ignoring the copyright year and owner,
+// it returns nil if the header is not found or the license has neither the year nor the owner.
+func matchCopyright(content string, config *ConfigHeader) *copyrightMatch {
+	patterns := config.copyrightPatterns()
+	if patterns.header == nil {
+		return nil
+	}
+	matches := patterns.header.FindStringSubmatchIndex(content)
+	if matches == nil {
+		return nil
+	}
+
+	m := &copyrightMatch{Offset: matches[0]}
+	for i, kind := range patterns.kinds {
+		found := content[matches[2*i+2]:matches[2*i+3]]
+		if kind == yearPlaceholder {
+			m.Year = found
+		} else {
+			m.Owner = found
+		}
+	}
+	return m
+}
+
+// satisfyYearPolicy returns whether the normalized content has the license header with a valid copyright year,
+// it's only necessary when the copyright-year-policy is set, as the year is not compared literally then.
+func satisfyYearPolicy(file, content string, config *ConfigHeader) bool {
+	if config.License.CopyrightYearPolicy == YearPolicyConfigured {
+		return false
+	}
+	m := matchCopyright(content, config)
+	if m == nil || m.Offset >= config.LicenseLocationThreshold {
+		return false
+	}
+	if m.Owner != "" && m.Owner != lcs.Normalize(config.License.CopyrightOwner) {
+		return false
+	}
+	return m.Year == "" || config.acceptsYear(file, m.Year)
+}
+
+// rewriteCopyrightYear replaces the copyright year in the existing license header of the content with the correct
+// one, leaving the rest of the content untouched. It returns false if the copyright year is not found.
+func rewriteCopyrightYear(file string, content []byte, config *ConfigHeader) ([]byte, bool) {
+	r := config.copyrightPatterns().year
+	if r == nil {
+		return nil, false
+	}
+
+	head := content
+	if len(head) > identifyHeadBytes {
+		head = head[:identifyHeadBytes]
+	}
+	loc := r.FindSubmatchIndex(head)
+	if loc == nil {
+		return nil, false
+	}
+
+	found := string(content[loc[4]:loc[5]])
+	year := config.copyrightYearOf(file, found)
+	if found == year {
+		return nil, false
+	}
+
+	rewritten := make([]byte, 0, len(content)+len(year)-len(found))
+	rewritten = append(rewritten, content[:loc[4]]...)
+	rewritten = append(rewritten, year...)
+	rewritten = append(rewritten, content[loc[5]:]...)
+	return rewritten, true
+}
+
+// copyrightPatterns are the patterns compiled from the configured license, to find the copyright year and owner in
+// the license headers.
+type copyrightPatterns struct {
+	once sync.Once
+	// header matches the normalized license header, capturing the placeholders in kinds, nil if there's none.
+	header *regexp.Regexp
+	kinds  []string
+	// year matches the copyright year with its leading words in the same line, nil if the year can't be located.
+	year *regexp.Regexp
+}
+
+// copyrightPatterns returns the copyright patterns of the configured license, which are compiled only once.
+func (config *ConfigHeader) copyrightPatterns() *copyrightPatterns {
+	if config.patterns == nil {
+		patterns := &copyrightPatterns{}
+		patterns.compile(config)
+		return patterns
+	}
+	config.patterns.once.Do(func() {
+		config.patterns.compile(config)
+	})
+	return config.patterns
+}
+
+func (patterns *copyrightPatterns) compile(config *ConfigHeader) {
+	template := config.normalizedLicenseWith(yearPlaceholder, ownerPlaceholder)
+	if placeholders.MatchString(template) {
+		pattern := ""
+		last := 0
+		for _, loc := range placeholders.FindAllStringIndex(template, -1) {
+			kind := template[loc[0]:loc[1]]
+			if kind == yearPlaceholder {
+				pattern += regexp.QuoteMeta(template[last:loc[0]]) + `(\d{4}(?:\s*[-,]\s*\d{4})*|.+?)`
+			} else {
+				pattern += regexp.QuoteMeta(template[last:loc[0]]) + "(.+?)"
+			}
+			patterns.kinds = append(patterns.kinds, kind)
+			last = loc[1]
+		}
+		pattern += regexp.QuoteMeta(template[last:])
+		patterns.header, _ = regexp.Compile(pattern)
+	}
+
+	template = config.licenseContent(yearPlaceholder, ownerPlaceholder)
+	index := strings.Index(template, yearPlaceholder)
+	if index < 0 {
+		return
+	}
+	// the words before the year in the same line, e.g. "Copyright (c)"
+	prefix := template[strings.LastIndex(template[:index], "\n")+1 : index]
+	prefix = strings.TrimSpace(placeholders.ReplaceAllString(prefix, ""))
+	words := strings.Fields(prefix)
+	if len(words) == 0 {
+		return // the year can't be located reliably without the leading words
+	}
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	patterns.year = regexp.MustCompile(`(?i)(` + strings.Join(words, `\s+`) + `[ \t]+)(\d{4}(?:[ \t]*[-,][ \t]*\d{4})*)`)
+}
@@ -0,0 +1,150 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package header
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/skywalking-eyes/pkg/comments"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyrightYearPolicy(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	repo, err := git.PlainInit(".", false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(file, content string, year int) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+		_, err := worktree.Add(file)
+		require.NoError(t, err)
+		_, err = worktree.Commit("update "+file, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC)},
+		})
+		require.NoError(t, err)
+	}
+	commit("main.go", "package main\n", 2019)
+	commit("main.go", "package main\n\nfunc main() {}\n", 2023)
+
+	current := time.Now().Year()
+	newConfig := func(policy CopyrightYearPolicy) *ConfigHeader {
+		c := &ConfigHeader{License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYearPolicy: policy}}
+		require.NoError(t, c.Finalize())
+		return c
+	}
+
+	tests := []struct {
+		policy   CopyrightYearPolicy
+		found    string
+		expected string
+	}{
+		{YearPolicyConfigured, "", fmt.Sprint(current)},
+		{YearPolicyCreationYear, "", "2019"},
+		{YearPolicyLastModified, "", "2023"},
+		{YearPolicyRangeToCurrent, "", fmt.Sprintf("2019-%d", current)},
+		{YearPolicyRangeToCurrent, "2015", fmt.Sprintf("2015-%d", current)},
+		{YearPolicyRangeToCurrent, "2021", fmt.Sprintf("2019-%d", current)},
+	}
+	for _, test := range tests {
+		t.Run(string(test.policy)+test.found, func(t *testing.T) {
+			require.Equal(t, test.expected, newConfig(test.policy).copyrightYearOf("main.go", test.found))
+		})
+	}
+
+	// the history is walked only until the years of the checked files are found
+	commit("other.go", "package main\n", 2024)
+	years := &fileYears{}
+	year, ok := years.createdYear("other.go")
+	require.True(t, ok)
+	require.Equal(t, 2024, year)
+	require.NotNil(t, years.next)
+	year, ok = years.createdYear("main.go")
+	require.True(t, ok)
+	require.Equal(t, 2019, year)
+	require.Nil(t, years.next)
+
+	// the files that are not committed yet are created and modified in the current year
+	require.Equal(t, fmt.Sprint(current), newConfig(YearPolicyCreationYear).copyrightYearOf("new.go", ""))
+	require.NoError(t, os.WriteFile("main.go", []byte("package main\n"), 0o644))
+	require.Equal(t, fmt.Sprint(current), newConfig(YearPolicyLastModified).copyrightYearOf("main.go", ""))
+
+	require.True(t, newConfig(YearPolicyAny).acceptsYear("main.go", "2010 - 2012"))
+	require.False(t, newConfig(YearPolicyAny).acceptsYear("main.go", "acme"))
+
+	require.Error(t, (&ConfigHeader{License: LicenseConfig{CopyrightYearPolicy: "unknown"}}).Finalize())
+}
+
+func TestCheckAndFixCopyrightYear(t *testing.T) {
+	c := &ConfigHeader{
+		License: LicenseConfig{SpdxID: "Apache-2.0", CopyrightOwner: "Acme", CopyrightYear: "2019", CopyrightYearPolicy: YearPolicyRangeToCurrent},
+	}
+	require.NoError(t, c.Finalize())
+
+	header := func(year string) string {
+		h, err := generateLicenseHeader(comments.FileCommentStyle("main.go"), c.licenseContent(year, "Acme"))
+		require.NoError(t, err)
+		return h
+	}
+	current := time.Now().Year()
+	expected := fmt.Sprintf("2019-%d", current)
+	body := "package main\n\n// Copyright 2000 is not in the header\nfunc main() {}\n"
+
+	for _, test := range []struct {
+		year  string
+		valid bool
+	}{
+		{expected, true},
+		{fmt.Sprintf("2012-%d", current), true},
+		{"2019", false},
+		{"2019-2020", false},
+	} {
+		t.Run(test.year, func(t *testing.T) {
+			content := []byte(header(test.year) + body)
+
+			result := &Result{}
+			checkContent("main.go", content, c, result)
+			if test.valid {
+				require.Equal(t, []string{"main.go"}, result.Success)
+				return
+			}
+			require.Equal(t, ReasonCopyrightYear, result.Diagnostic("main.go").Reason)
+
+			fixed, ok := rewriteCopyrightYear("main.go", content, c)
+			require.True(t, ok)
+			require.Equal(t, header(expected)+body, string(fixed))
+			require.Equal(t, 1, strings.Count(string(fixed), "Licensed under the Apache License"))
+
+			result = &Result{}
+			checkContent("main.go", fixed, c, result)
+			require.False(t, result.HasFailure())
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package license
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/apache/skywalking-eyes/assets"
+)
+
+var (
+	knownIDs     map[string]string
+	knownIDsOnce sync.Once
+
+	licenseRef = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.\-]+:)?LicenseRef-[A-Za-z0-9.\-]+$`)
+)
+
+// canonicalID returns the canonical SPDX ID (or exception ID) of the case-insensitive id,
+// the known IDs are the ones that have templates in the lcs-templates.
+func canonicalID(id string) (string, bool) {
+	knownIDsOnce.Do(func() {
+		knownIDs = make(map[string]string)
+		if entries, err := assets.AssetDir(licenseTemplatesDir); err == nil {
+			for _, entry := range entries {
+				id := strings.TrimSuffix(entry.Name(), ".txt")
+				knownIDs[strings.ToLower(id)] = id
+			}
+		}
+	})
+	canonical, ok := knownIDs[strings.ToLower(id)]
+	return canonical, ok
+}
+
+// IsKnownID returns whether the id is a known SPDX license ID or exception ID, case-insensitively.
+func IsKnownID(id string) bool {
+	_, ok := canonicalID(id)
+	return ok
+}
+
+// ParseExpression parses the SPDX license expression, and returns its canonical form, in which the operators are
+// upper-cased, the license IDs are in their canonical cases, and the tokens are separated by single spaces.
+// An error is returned if the expression is malformed, or it refers to unknown license IDs. The custom licenses
+// in the form of LicenseRef-[idstring] and DocumentRef-[idstring]:LicenseRef-[idstring] are always valid.
+func ParseExpression(expression string) (string, error) {
+	p := &expressionParser{tokens: tokenizeExpression(expression)}
+	if len(p.tokens) == 0 {
+		return "", fmt.Errorf("the license expression is empty")
+	}
+	if err := p.compound(); err != nil {
+		return "", fmt.Errorf("invalid license expression %q: %w", expression, err)
+	}
+	if p.pos < len(p.tokens) {
+		return "", fmt.Errorf("invalid license expression %q: unexpected %q", expression, p.tokens[p.pos])
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(strings.Join(p.out, " "), "( ", "("), " )", ")"), nil
+}
+
+func tokenizeExpression(expression string) []string {
+	expression = strings.ReplaceAll(expression, "(", " ( ")
+	expression = strings.ReplaceAll(expression, ")", " ) ")
+	return strings.Fields(expression)
+}
+
+type expressionParser struct {
+	tokens []string
+	pos    int
+	out    []string
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *expressionParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+// compound = term { ("AND" | "OR") term }
+func (p *expressionParser) compound() error {
+	if err := p.term(); err != nil {
+		return err
+	}
+	for {
+		operator := strings.ToUpper(p.peek())
+		if operator != "AND" && operator != "OR" {
+			return nil
+		}
+		p.out = append(p.out, operator)
+		p.next()
+		if err := p.term(); err != nil {
+			return err
+		}
+	}
+}
+
+// term = "(" compound ")" | license [ "WITH" exception ]
+func (p *expressionParser) term() error {
+	token := p.next()
+	switch token {
+	case "":
+		return fmt.Errorf("unexpected end of the expression")
+	case "(":
+		p.out = append(p.out, "(")
+		if err := p.compound(); err != nil {
+			return err
+		}
+		if p.next() != ")" {
+			return fmt.Errorf("missing the closing parenthesis")
+		}
+		p.out = append(p.out, ")")
+		return nil
+	case ")":
+		return fmt.Errorf("unexpected %q", token)
+	}
+
+	license, err := licenseID(token)
+	if err != nil {
+		return err
+	}
+	p.out = append(p.out, license)
+
+	if strings.ToUpper(p.peek()) == "WITH" {
+		p.next()
+		exception := p.next()
+		if exception == "" || exception == "(" || exception == ")" {
+			return fmt.Errorf("missing the exception after WITH")
+		}
+		canonical, ok := canonicalID(exception)
+		if !ok {
+			return fmt.Errorf("unknown license exception %q", exception)
+		}
+		p.out = append(p.out, "WITH", canonical)
+	}
+	return nil
+}
+
+func licenseID(token string) (string, error) {
+	switch strings.ToUpper(token) {
+	case "AND", "OR", "WITH":
+		return "", fmt.Errorf("unexpected operator %q", token)
+	}
+	if licenseRef.MatchString(token) {
+		return token, nil
+	}
+
+	id, plus := strings.TrimSuffix(token, "+"), strings.HasSuffix(token, "+")
+	canonical, ok := canonicalID(id)
+	if !ok {
+		return "", fmt.Errorf("unknown license ID %q", id)
+	}
+	if plus {
+		canonical += "+"
+	}
+	return canonical, nil
+}
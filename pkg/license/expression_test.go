@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package license
+
+import (
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+		wantErr    bool
+	}{
+		{expression: "Apache-2.0", want: "Apache-2.0"},
+		{expression: "apache-2.0", want: "Apache-2.0"},
+		{expression: "MIT or  Apache-2.0", want: "MIT OR Apache-2.0"},
+		{expression: "(MIT OR Apache-2.0) AND BSD-3-Clause", want: "(MIT OR Apache-2.0) AND BSD-3-Clause"},
+		{expression: "GPL-2.0-or-later WITH Classpath-exception-2.0", want: "GPL-2.0-or-later WITH Classpath-exception-2.0"},
+		{expression: "LGPL-2.1+", want: "LGPL-2.1+"},
+		{expression: "LicenseRef-Proprietary", want: "LicenseRef-Proprietary"},
+		{expression: "DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2", want: "DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2"},
+		{expression: "", wantErr: true},
+		{expression: "Not-A-License", wantErr: true},
+		{expression: "MIT OR", wantErr: true},
+		{expression: "MIT Apache-2.0", wantErr: true},
+		{expression: "(MIT OR Apache-2.0", wantErr: true},
+		{expression: "MIT WITH", wantErr: true},
+		{expression: "MIT WITH Not-An-Exception", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := ParseExpression(tt.expression)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseExpression() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseExpression() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
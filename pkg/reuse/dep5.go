@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package reuse
+
+import (
+	"os"
+	"strings"
+)
+
+// ParseDep5 parses the file paragraphs in the .reuse/dep5 file, which is in the format of the Debian copyright file.
+// The information in dep5 is aggregated with the one in the files.
+func ParseDep5(file string) ([]*Annotation, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []*Annotation
+	for _, paragraph := range strings.Split(strings.ReplaceAll(string(bs), "\r\n", "\n"), "\n\n") {
+		fields := parseDep5Fields(paragraph)
+		if len(fields["Files"]) == 0 {
+			continue // the header paragraph or a stand-alone license paragraph
+		}
+
+		annotation := &Annotation{Precedence: PrecedenceAggregate, dep5: true}
+		for _, files := range fields["Files"] {
+			annotation.Paths = append(annotation.Paths, strings.Fields(files)...)
+		}
+		for _, copyright := range fields["Copyright"] {
+			for _, line := range strings.Split(copyright, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					annotation.Copyrights = append(annotation.Copyrights, line)
+				}
+			}
+		}
+		for _, license := range fields["License"] {
+			// the first line is the license expression, the rest is the license text
+			annotation.Licenses = append(annotation.Licenses, strings.TrimSpace(strings.SplitN(license, "\n", 2)[0]))
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations, nil
+}
+
+// parseDep5Fields parses the fields of a paragraph, the continuation lines start with spaces.
+func parseDep5Fields(paragraph string) map[string][]string {
+	fields := make(map[string][]string)
+	var key string
+	for _, line := range strings.Split(paragraph, "\n") {
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		if key != "" && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			values := fields[key]
+			values[len(values)-1] += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(k)
+		fields[key] = append(fields[key], strings.TrimSpace(v))
+	}
+	return fields
+}
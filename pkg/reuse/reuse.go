@@ -0,0 +1,367 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package reuse checks the compliance of the repository with the REUSE specification 3.x, see https://reuse.software.
+package reuse
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/skywalking-eyes/internal/logger"
+	"github.com/apache/skywalking-eyes/pkg/header"
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+const (
+	// LicensesDir is the directory holding the texts of all the licenses used in the repository.
+	LicensesDir = "LICENSES"
+
+	tomlFile = "REUSE.toml"
+	dep5File = ".reuse/dep5"
+
+	// identifyThreshold is the coverage threshold to identify the license texts in LICENSES.
+	identifyThreshold = 75
+)
+
+var (
+	copyrightTag = regexp.MustCompile(`(?:SPDX-(?:File|Snippet)CopyrightText:|Copyright\b|©)\s*(.*)`)
+	licenseTag   = regexp.MustCompile(`SPDX-License-Identifier:\s*(.*)`)
+	// the comment closers to strip from the end of the tag values
+	commentCloser = regexp.MustCompile(`\s*(\*/|-->|--}}|#}|%>|-}|\*\)|"""|''')\s*$`)
+	ignoreStart   = "REUSE-IgnoreStart"
+	ignoreEnd     = "REUSE-IgnoreEnd"
+)
+
+// Info is the licensing information of a file.
+type Info struct {
+	Copyrights []string
+	Licenses   []string
+}
+
+// Report is the result of the REUSE lint.
+type Report struct {
+	Files int
+
+	MissingCopyright   []string
+	MissingLicense     []string
+	InvalidExpressions []string
+
+	MissingLicenses    []string
+	UnusedLicenses     []string
+	BadLicenses        []string
+	MismatchedLicenses []string
+}
+
+// Compliant returns whether the repository complies with the REUSE specification.
+func (report *Report) Compliant() bool {
+	return len(report.MissingCopyright) == 0 && len(report.MissingLicense) == 0 &&
+		len(report.InvalidExpressions) == 0 && len(report.MissingLicenses) == 0 &&
+		len(report.UnusedLicenses) == 0 && len(report.BadLicenses) == 0 && len(report.MismatchedLicenses) == 0
+}
+
+func (report *Report) String() string {
+	var sb strings.Builder
+	section := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("%v (%d):\n", title, len(items)))
+		for _, item := range items {
+			sb.WriteString("  " + item + "\n")
+		}
+	}
+	section("Bad licenses", report.BadLicenses)
+	section("Missing licenses", report.MissingLicenses)
+	section("Unused licenses", report.UnusedLicenses)
+	section("Licenses not matching their texts", report.MismatchedLicenses)
+	section("Invalid license expressions", report.InvalidExpressions)
+	section("Files without copyright information", report.MissingCopyright)
+	section("Files without license information", report.MissingLicense)
+
+	sb.WriteString(fmt.Sprintf(
+		"Summary: %d file(s) checked, %d without copyright information, %d without license information",
+		report.Files, len(report.MissingCopyright), len(report.MissingLicense),
+	))
+	return sb.String()
+}
+
+// Lint checks the files in the current directory against the REUSE specification.
+func Lint() (*Report, error) {
+	annotations, err := loadAnnotations()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := header.ListFiles(&header.ConfigHeader{Paths: []string{"**"}})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	used := make(map[string][]string)
+	for _, file := range files {
+		file = filepath.ToSlash(filepath.Clean(file))
+		if !covered(file) {
+			continue
+		}
+		report.Files++
+
+		info, err := InfoOf(file, annotations)
+		if err != nil {
+			return nil, err
+		}
+		if len(info.Copyrights) == 0 {
+			report.MissingCopyright = append(report.MissingCopyright, file)
+		}
+		if len(info.Licenses) == 0 {
+			report.MissingLicense = append(report.MissingLicense, file)
+		}
+		for _, expression := range info.Licenses {
+			if _, err := lcs.ParseExpression(expression); err != nil {
+				report.InvalidExpressions = append(report.InvalidExpressions, fmt.Sprintf("%v: %v", file, err))
+				continue
+			}
+			for _, id := range licenseIDs(expression) {
+				used[id] = append(used[id], file)
+			}
+		}
+	}
+
+	if err := checkLicenses(used, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// covered returns whether the file must carry the licensing information, the license texts, the
+// REUSE metadata, and the sidecar files are not covered.
+func covered(file string) bool {
+	base := filepath.Base(file)
+	upper := strings.ToUpper(base)
+	switch {
+	case file == ".git" || strings.HasPrefix(file, ".git/"):
+		return false
+	case strings.HasPrefix(file, LicensesDir+"/") || strings.HasPrefix(file, ".reuse/"):
+		return false
+	case strings.HasPrefix(upper, "LICENSE") || strings.HasPrefix(upper, "LICENCE") || strings.HasPrefix(upper, "COPYING"):
+		return false
+	case file == tomlFile || strings.HasSuffix(base, header.SidecarSuffix) || strings.HasSuffix(base, ".spdx"):
+		return false
+	}
+	return true
+}
+
+func loadAnnotations() ([]*Annotation, error) {
+	_, tomlErr := os.Stat(tomlFile)
+	_, dep5Err := os.Stat(dep5File)
+	switch {
+	case tomlErr == nil && dep5Err == nil:
+		return nil, fmt.Errorf("%v and %v cannot be used at the same time", tomlFile, dep5File)
+	case tomlErr == nil:
+		return ParseTOML(tomlFile)
+	case dep5Err == nil:
+		logger.Log.Warnf("%v is deprecated, please migrate to %v", dep5File, tomlFile)
+		return ParseDep5(dep5File)
+	}
+	return nil, nil
+}
+
+// InfoOf returns the licensing information of the file, from its sidecar file or its content, combined with the
+// information in the last annotation of the REUSE.toml or .reuse/dep5 that matches the file.
+func InfoOf(file string, annotations []*Annotation) (*Info, error) {
+	info := &Info{}
+	if _, err := os.Stat(file + header.SidecarSuffix); err == nil {
+		if info, err = ReadInfo(file + header.SidecarSuffix); err != nil {
+			return nil, err
+		}
+	} else if bs, err := os.ReadFile(file); err != nil {
+		return nil, err
+	} else if strings.HasPrefix(http.DetectContentType(bs), "text/") {
+		info = ParseInfo(bs)
+	}
+
+	var annotation *Annotation
+	for _, a := range annotations {
+		if a.Matches(file) {
+			annotation = a
+		}
+	}
+	if annotation == nil {
+		return info, nil
+	}
+
+	switch annotation.Precedence {
+	case PrecedenceOverride:
+		return &Info{Copyrights: annotation.Copyrights, Licenses: annotation.Licenses}, nil
+	case PrecedenceAggregate:
+		info.Copyrights = append(info.Copyrights, annotation.Copyrights...)
+		info.Licenses = append(info.Licenses, annotation.Licenses...)
+	default:
+		if len(info.Copyrights) == 0 {
+			info.Copyrights = annotation.Copyrights
+		}
+		if len(info.Licenses) == 0 {
+			info.Licenses = annotation.Licenses
+		}
+	}
+	return info, nil
+}
+
+// ReadInfo reads the licensing information in the file.
+func ReadInfo(file string) (*Info, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInfo(bs), nil
+}
+
+// ParseInfo parses the SPDX-FileCopyrightText (or the copyright notices) and SPDX-License-Identifier tags
+// in the content, the lines between REUSE-IgnoreStart and REUSE-IgnoreEnd are skipped.
+func ParseInfo(content []byte) *Info {
+	info := &Info{}
+	ignoring := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.Contains(line, ignoreStart):
+			ignoring = true
+			continue
+		case strings.Contains(line, ignoreEnd):
+			ignoring = false
+			continue
+		case ignoring:
+			continue
+		}
+
+		if m := licenseTag.FindStringSubmatch(line); m != nil {
+			if value := tagValue(m[1]); value != "" {
+				info.Licenses = append(info.Licenses, value)
+			}
+		} else if m := copyrightTag.FindStringSubmatch(line); m != nil {
+			if value := tagValue(m[1]); value != "" {
+				info.Copyrights = append(info.Copyrights, strings.TrimSpace(m[0][:len(m[0])-len(m[1])])+" "+value)
+			}
+		}
+	}
+	return info
+}
+
+func tagValue(value string) string {
+	return strings.TrimSpace(commentCloser.ReplaceAllString(strings.TrimSpace(value), ""))
+}
+
+// licenseIDs returns the license and exception IDs in the valid expression, the "+" operators are stripped.
+func licenseIDs(expression string) []string {
+	var ids []string
+	for _, token := range strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expression)) {
+		switch strings.ToUpper(token) {
+		case "AND", "OR", "WITH":
+			continue
+		}
+		token = strings.TrimSuffix(token, "+")
+		if strings.HasPrefix(token, "DocumentRef-") {
+			continue // the license text is in the referred document
+		}
+		if canonical, err := lcs.ParseExpression(token); err == nil {
+			token = canonical
+		}
+		ids = append(ids, token)
+	}
+	return ids
+}
+
+// checkLicenses checks the license texts in the LICENSES directory against the licenses used by the files.
+func checkLicenses(used map[string][]string, report *Report) error {
+	entries, err := os.ReadDir(LicensesDir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	present := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		id := strings.TrimSuffix(name, filepath.Ext(name))
+		if !lcs.IsKnownID(id) && !strings.HasPrefix(id, "LicenseRef-") {
+			report.BadLicenses = append(report.BadLicenses, filepath.ToSlash(filepath.Join(LicensesDir, name)))
+			continue
+		}
+		if canonical, err := lcs.ParseExpression(id); err == nil {
+			id = canonical
+		}
+		present[id] = true
+
+		if len(used[id]) == 0 {
+			report.UnusedLicenses = append(report.UnusedLicenses, id)
+		}
+		if strings.HasPrefix(id, "LicenseRef-") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(LicensesDir, name))
+		if err != nil {
+			return err
+		}
+		if !matches(id, string(content)) {
+			report.MismatchedLicenses = append(report.MismatchedLicenses, filepath.ToSlash(filepath.Join(LicensesDir, name)))
+		}
+	}
+
+	for id, files := range used {
+		if !present[id] {
+			report.MissingLicenses = append(report.MissingLicenses, fmt.Sprintf("%v (used by %v)", id, strings.Join(files, ", ")))
+		}
+	}
+	sort.Strings(report.MissingLicenses)
+
+	return nil
+}
+
+// matches returns whether the content is the text of the license id, i.e. it's identified as the license, or it
+// contains the whole license text, a fragment of the license text doesn't match.
+func matches(id, content string) bool {
+	if identified, err := lcs.Identify(content, identifyThreshold); err == nil {
+		for _, identifiedID := range strings.Fields(identified) {
+			if strings.EqualFold(identifiedID, id) {
+				return true
+			}
+		}
+	}
+
+	template, err := lcs.GetLicenseContent(id)
+	if err != nil {
+		return false
+	}
+	normalizedContent, normalizedTemplate := lcs.Normalize(content), lcs.Normalize(template)
+	if normalizedContent == "" {
+		return false
+	}
+	return strings.Contains(normalizedContent, normalizedTemplate)
+}
@@ -0,0 +1,169 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package reuse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	lcs "github.com/apache/skywalking-eyes/pkg/license"
+)
+
+func writeFiles(t *testing.T, files map[string]string) {
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	}
+}
+
+func inTempDir(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	require.NoError(t, os.Chdir(t.TempDir()))
+}
+
+func apacheText(t *testing.T) string {
+	text, err := lcs.GetLicenseContent("Apache-2.0")
+	require.NoError(t, err)
+	return text
+}
+
+func TestParseInfo(t *testing.T) {
+	info := ParseInfo([]byte(`/*
+ * SPDX-FileCopyrightText: 2024 Apache Software Foundation */
+// Copyright 2023 Someone
+// SPDX-License-Identifier: Apache-2.0 */
+// REUSE-IgnoreStart
+// SPDX-License-Identifier: MIT
+// REUSE-IgnoreEnd
+// Copyright
+`))
+	require.Equal(t, []string{"SPDX-FileCopyrightText: 2024 Apache Software Foundation", "Copyright 2023 Someone"}, info.Copyrights)
+	require.Equal(t, []string{"Apache-2.0"}, info.Licenses)
+}
+
+func TestParseTOML(t *testing.T) {
+	inTempDir(t)
+	writeFiles(t, map[string]string{
+		"REUSE.toml": `version = 1
+
+[[annotations]]
+path = ["docs/**", "*.png"] # images and docs
+precedence = "override"
+SPDX-FileCopyrightText = "2024 Someone"
+SPDX-License-Identifier = "CC-BY-4.0"
+
+[[annotations]]
+path = [
+  "vendor/**",
+]
+SPDX-FileCopyrightText = ["2024 Someone", '2024 Another # one']
+SPDX-License-Identifier = "MIT"
+`,
+	})
+
+	annotations, err := ParseTOML("REUSE.toml")
+	require.NoError(t, err)
+	require.Len(t, annotations, 2)
+	require.Equal(t, []string{"docs/**", "*.png"}, annotations[0].Paths)
+	require.Equal(t, PrecedenceOverride, annotations[0].Precedence)
+	require.Equal(t, []string{"CC-BY-4.0"}, annotations[0].Licenses)
+	require.Equal(t, PrecedenceClosest, annotations[1].Precedence)
+	require.Equal(t, []string{"2024 Someone", "2024 Another # one"}, annotations[1].Copyrights)
+	require.True(t, annotations[1].Matches("vendor/a/b.go"))
+	require.False(t, annotations[0].Matches("docs"))
+
+	writeFiles(t, map[string]string{"REUSE.toml": "version = 1\n[[annotations]]\npath = \"a\"\nunknown = \"b\"\n"})
+	_, err = ParseTOML("REUSE.toml")
+	require.ErrorContains(t, err, `REUSE.toml:4: unknown key "unknown"`)
+}
+
+func TestParseDep5(t *testing.T) {
+	inTempDir(t)
+	writeFiles(t, map[string]string{
+		".reuse/dep5": `Format: https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/
+Upstream-Name: eyes
+
+Files: images/* docs/*.md
+Copyright: 2024 Someone
+ 2024 Another
+License: MIT
+`,
+	})
+
+	annotations, err := ParseDep5(".reuse/dep5")
+	require.NoError(t, err)
+	require.Len(t, annotations, 1)
+	require.Equal(t, []string{"2024 Someone", "2024 Another"}, annotations[0].Copyrights)
+	require.Equal(t, []string{"MIT"}, annotations[0].Licenses)
+	require.True(t, annotations[0].Matches("images/a/b.png"))
+	require.False(t, annotations[0].Matches("src/a.go"))
+}
+
+func TestLint(t *testing.T) {
+	inTempDir(t)
+	writeFiles(t, map[string]string{
+		"LICENSES/Apache-2.0.txt": apacheText(t),
+		"main.go":                 "// SPDX-FileCopyrightText: 2024 Someone\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+		"logo.png":                "\x89PNG\r\n\x1a\n\x00\x00",
+		"logo.png.license":        "SPDX-FileCopyrightText: 2024 Someone\nSPDX-License-Identifier: Apache-2.0\n",
+		"docs/index.md":           "# Docs\n",
+		"REUSE.toml":              "version = 1\n\n[[annotations]]\npath = \"docs/**\"\nSPDX-FileCopyrightText = \"2024 Someone\"\nSPDX-License-Identifier = \"Apache-2.0\"\n",
+	})
+
+	report, err := Lint()
+	require.NoError(t, err)
+	require.True(t, report.Compliant(), report.String())
+	require.Equal(t, 3, report.Files)
+
+	writeFiles(t, map[string]string{
+		"LICENSES/MIT.txt": apacheText(t),
+		"LICENSES/Foo.txt": "foo",
+		"LICENSES/ISC.txt": "Permission to use, copy, modify, and/or distribute this software for any purpose with or without fee is hereby granted,\n",
+		"util.go":          "// SPDX-License-Identifier: GPL-2.0-only WITH Classpath-exception-2.0\n\npackage main\n",
+		"broken.go":        "// SPDX-FileCopyrightText: 2024 Someone\n// SPDX-License-Identifier: Apache-2.0 AND\n\npackage main\n",
+		"docs/index.md":    "# Docs\n",
+		"scripts/run.sh":   "#!/bin/sh\n",
+		".reuse/.keep":     "",
+		"LICENSE":          apacheText(t),
+	})
+
+	report, err = Lint()
+	require.NoError(t, err)
+	require.False(t, report.Compliant())
+	require.Equal(t, []string{"scripts/run.sh", "util.go"}, report.MissingCopyright)
+	require.Equal(t, []string{"scripts/run.sh"}, report.MissingLicense)
+	require.Len(t, report.InvalidExpressions, 1)
+	require.Contains(t, report.InvalidExpressions[0], "broken.go")
+	require.Equal(t, []string{"Classpath-exception-2.0 (used by util.go)", "GPL-2.0-only (used by util.go)"}, report.MissingLicenses)
+	require.Equal(t, []string{"ISC", "MIT"}, report.UnusedLicenses)
+	require.Equal(t, []string{"LICENSES/Foo.txt"}, report.BadLicenses)
+	require.Equal(t, []string{"LICENSES/ISC.txt", "LICENSES/MIT.txt"}, report.MismatchedLicenses)
+}
+
+func TestLintBothTOMLAndDep5(t *testing.T) {
+	inTempDir(t)
+	writeFiles(t, map[string]string{"REUSE.toml": "version = 1\n", ".reuse/dep5": "Format: x\n"})
+
+	_, err := Lint()
+	require.Error(t, err)
+}
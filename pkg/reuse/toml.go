@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package reuse
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v2"
+
+	"github.com/apache/skywalking-eyes/internal/toml"
+)
+
+// Precedence decides how the information in an annotation is combined with the one in the file.
+type Precedence string
+
+const (
+	// PrecedenceClosest uses the information in the file, and the annotation only for the missing kinds of it.
+	PrecedenceClosest Precedence = "closest"
+	// PrecedenceAggregate combines the information in the file and the annotation.
+	PrecedenceAggregate Precedence = "aggregate"
+	// PrecedenceOverride uses the information in the annotation only.
+	PrecedenceOverride Precedence = "override"
+)
+
+// Annotation is an [[annotations]] table in REUSE.toml, or a paragraph in .reuse/dep5.
+type Annotation struct {
+	Paths      []string
+	Precedence Precedence
+	Copyrights []string
+	Licenses   []string
+
+	// dep5 paths are matched with * matching the slashes too
+	dep5 bool
+}
+
+// Matches returns whether the file (a slash-separated path relative to the root) is annotated.
+func (annotation *Annotation) Matches(file string) bool {
+	for _, pattern := range annotation.Paths {
+		if annotation.dep5 {
+			pattern = strings.ReplaceAll(pattern, "*", "**")
+		}
+		if ok, err := doublestar.Match(pattern, file); ok && err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTOML parses the annotations in the REUSE.toml file, only the version key, the [[annotations]] tables, and the
+// string or string array values are supported.
+func ParseTOML(file string) ([]*Annotation, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := toml.Parse(string(bs))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", file, err)
+	}
+
+	var annotations []*Annotation
+	for _, table := range tables {
+		if table.Name == "" {
+			for _, key := range table.Keys {
+				if key != "version" {
+					return nil, fmt.Errorf("%v:%d: unknown key %q", file, table.Lines[key], key)
+				}
+				if value := table.Values[key]; value != "1" {
+					return nil, fmt.Errorf("%v:%d: unsupported version %v", file, table.Lines[key], value)
+				}
+			}
+			continue
+		}
+		if table.Name != "annotations" || !table.Array {
+			return nil, fmt.Errorf("%v: unsupported table %q", file, table.Name)
+		}
+
+		annotation := &Annotation{Precedence: PrecedenceClosest}
+		annotations = append(annotations, annotation)
+		for _, key := range table.Keys {
+			line := table.Lines[key]
+			values, err := table.Strings(key)
+			if err != nil {
+				return nil, fmt.Errorf("%v:%d: %w", file, line, err)
+			}
+			switch key {
+			case "path":
+				annotation.Paths = append(annotation.Paths, values...)
+			case "precedence":
+				if len(values) != 1 {
+					return nil, fmt.Errorf("%v:%d: precedence must be a string", file, line)
+				}
+				switch p := Precedence(values[0]); p {
+				case PrecedenceClosest, PrecedenceAggregate, PrecedenceOverride:
+					annotation.Precedence = p
+				default:
+					return nil, fmt.Errorf("%v:%d: unknown precedence %q", file, line, p)
+				}
+			case "SPDX-FileCopyrightText":
+				annotation.Copyrights = append(annotation.Copyrights, values...)
+			case "SPDX-License-Identifier":
+				annotation.Licenses = append(annotation.Licenses, values...)
+			default:
+				return nil, fmt.Errorf("%v:%d: unknown key %q", file, line, key)
+			}
+		}
+		if len(annotation.Paths) == 0 {
+			return nil, fmt.Errorf("%v: an annotation has no path", file)
+		}
+	}
+
+	return annotations, nil
+}
@@ -258,6 +258,12 @@ func IsPR() bool {
 }
 
 func Markdown(result *header2.Result) string {
+	failures := make([]string, 0, len(result.Failure))
+	for _, file := range result.Failure {
+		d := result.Diagnostic(file)
+		failures = append(failures, fmt.Sprintf("`%s`: %s", file, d.Message))
+	}
+
 	return fmt.Sprintf(`
 <!-- %s -->
 [license-eye](https://github.com/apache/skywalking-eyes/tree/main/cmd/license-eye) has checked %d files.
@@ -285,7 +291,7 @@ func Markdown(result *header2.Result) string {
 		len(result.Failure),
 		len(result.Ignored),
 		len(result.Fixed),
-		"- "+strings.Join(result.Failure, "\n- "),
+		"- "+strings.Join(failures, "\n- "),
 	)
 }
 